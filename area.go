@@ -0,0 +1,32 @@
+package filmore
+
+// Area returns the area of p in square pixels: the sum of the signed areas of
+// its contours, as from ContourAreas, so that counters take away from the
+// outer contours around them. For text with well-formed glyphs it is the
+// area covered by ink. Use ContourAreas or Contours to look at contours one
+// by one, such as to find holes by their negative areas.
+func (p *TextPath) Area() float64 {
+	total := 0.0
+	for _, a := range p.ContourAreas() {
+		total += a
+	}
+	return total
+}
+
+// Centroid returns the center of mass of p's area, as measured by Area, for
+// balancing logos and for the mass properties of parts cut from it. Curves
+// are followed to within a small fraction of a pixel. ok is false if p has
+// no area.
+func (p *TextPath) Centroid() (x, y float64, ok bool) {
+	var area, sx, sy float64
+	for _, poly := range p.Flatten(defaultTolerance) {
+		a, c := polygonCenter(poly)
+		area += a
+		sx += a * c.X
+		sy += a * c.Y
+	}
+	if area == 0 {
+		return 0, 0, false
+	}
+	return sx / area, sy / area, true
+}
@@ -0,0 +1,44 @@
+package filmore
+
+// An Orientation is a direction across the page.
+type Orientation int
+
+const (
+	Horizontal Orientation = iota
+	Vertical
+)
+
+// rectPath returns a closed rectangle wound like a glyph's outer contour.
+func rectPath(x0, y0, x1, y1 float64) TextPath {
+	var p TextPath
+	p.MoveTo(x0, y0)
+	p.LineTo(x1, y0)
+	p.LineTo(x1, y1)
+	p.LineTo(x0, y1)
+	p.LineTo(x0, y0)
+	return p
+}
+
+// Bands slices p into n bands of equal size spanning its bounding box, and
+// returns the part of p inside each. Horizontal bands are ordered top to
+// bottom and vertical ones left to right. Filling each band with its own
+// flat color fakes a gradient in formats that lack them. Bands that p doesn't
+// reach are returned empty, so band i always covers the same stretch.
+func (p *TextPath) Bands(n int, o Orientation) []TextPath {
+	minX, minY, maxX, maxY, ok := polylineBounds(p.Flatten(defaultTolerance))
+	if !ok || n <= 0 {
+		return nil
+	}
+	bands := make([]TextPath, n)
+	for i := range bands {
+		t0, t1 := float64(i)/float64(n), float64(i+1)/float64(n)
+		var r TextPath
+		if o == Horizontal {
+			r = rectPath(minX, minY+t0*(maxY-minY), maxX, minY+t1*(maxY-minY))
+		} else {
+			r = rectPath(minX+t0*(maxX-minX), minY, minX+t1*(maxX-minX), maxY)
+		}
+		bands[i] = p.Intersect(&r)
+	}
+	return bands
+}
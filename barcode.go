@@ -0,0 +1,117 @@
+package filmore
+
+import "fmt"
+
+// A Symbology is a kind of linear barcode, which decides where its
+// human-readable line goes.
+type Symbology int
+
+const (
+	// Code128 has its text centered under the bars, with quiet zones of ten
+	// modules.
+	Code128 Symbology = iota
+	// Code39 is laid out like Code128.
+	Code39
+	// EAN13 has 13 digits: the first in the left quiet zone, and two groups
+	// of six under the halves of the symbol.
+	EAN13
+	// EAN8 has 8 digits, in two groups of four under the halves of the
+	// symbol.
+	EAN8
+	// UPCA has 12 digits: the first and last in the quiet zones, and two
+	// groups of five under the halves of the symbol.
+	UPCA
+)
+
+// Human-readable digits of EAN and UPC symbols are OCR-B figures 2.75mm tall
+// for a module 0.33mm wide, their tops half a module below the bars.
+const (
+	barcodeTextHeight = 2.75 / 0.33
+	barcodeTextGap    = 0.5
+)
+
+// QuietZones returns the width of the blank margins a symbol of symbology s
+// needs to the left and right of its bars, in modules.
+func (s Symbology) QuietZones() (left, right int) {
+	switch s {
+	case EAN13:
+		return 11, 7
+	case EAN8:
+		return 7, 7
+	case UPCA:
+		return 9, 9
+	}
+	return 10, 10
+}
+
+// BarcodeText returns the human-readable line of a barcode whose bars start
+// at x and whose data bars end at y, for label printing that draws the bars
+// itself. module is the width of the narrowest bar in pixels, and the text is
+// scaled from the font to the height the symbology's standard gives for that
+// module width, so f should be an OCR-B font where the standard asks for one.
+//
+// modules is the width of the bars in modules, over which the text of Code128
+// and Code39 is centered; it is ignored for EAN and UPC symbols, whose width
+// is fixed and whose digits go under the halves of the symbol and in its
+// quiet zones. Their text must be all of the symbol's digits, including the
+// check digit. The returned path's Width is that of the symbol together with
+// its quiet zones.
+func (f *Font) BarcodeText(s Symbology, text string, x, y, module float64, modules int) (TextPath, error) {
+	var groups []digitGroup
+	switch s {
+	case EAN13:
+		groups, modules = []digitGroup{{-7, 1}, {3, 6}, {50, 6}}, 95
+	case EAN8:
+		groups, modules = []digitGroup{{3, 4}, {36, 4}}, 67
+	case UPCA:
+		groups, modules = []digitGroup{{-7, 1}, {10, 5}, {50, 5}, {95, 1}}, 95
+	}
+	runes := []rune(text)
+	if groups != nil {
+		n := 0
+		for _, g := range groups {
+			n += g.count
+		}
+		if len(runes) != n {
+			return TextPath{}, fmt.Errorf("filmore: barcode text %q should have %d digits", text, n)
+		}
+		for _, r := range runes {
+			if r < '0' || r > '9' {
+				return TextPath{}, fmt.Errorf("filmore: barcode text %q should have only digits", text)
+			}
+		}
+	}
+	scale := 1.0
+	if h := f.CapHeight(); h > 0 {
+		scale = barcodeTextHeight * module / h
+	}
+	baseline := y + (barcodeTextGap+barcodeTextHeight)*module
+	left, right := s.QuietZones()
+	result := TextPath{Width: float64(left+modules+right) * module}
+	// place appends str scaled about its center, which goes at cx.
+	place := func(str string, cx float64) {
+		p := f.CreateTextPath(str, 0, 0)
+		p = p.mapPoints(func(px, py float64) (float64, float64) {
+			return cx + (px-p.Width/2)*scale, baseline + py*scale
+		})
+		result.PathOps = append(result.PathOps, p.PathOps...)
+	}
+	if groups == nil {
+		place(text, x+float64(modules)*module/2)
+		return result, nil
+	}
+	// Each digit is centered on the seven modules that encode it.
+	for _, g := range groups {
+		for k := 0; k < g.count; k++ {
+			place(string(runes[0]), x+(float64(g.start+7*k)+3.5)*module)
+			runes = runes[1:]
+		}
+	}
+	return result, nil
+}
+
+// A digitGroup is a run of digits of an EAN or UPC symbol's text, starting
+// under the given module.
+type digitGroup struct {
+	start, count int
+}
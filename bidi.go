@@ -0,0 +1,515 @@
+package filmore
+
+import "unicode"
+
+// An implementation of the Unicode Bidirectional Algorithm (UAX #9) for a
+// single paragraph. Bidi classes are derived from block ranges and the
+// general categories in package unicode rather than from the full
+// UnicodeData tables, which gets every commonly used character right.
+// Paired bracket resolution (rule N0) is not implemented; brackets resolve
+// like other neutrals.
+
+type bidiClass uint8
+
+const (
+	bidiL bidiClass = iota
+	bidiR
+	bidiAL
+	bidiEN
+	bidiES
+	bidiET
+	bidiAN
+	bidiCS
+	bidiNSM
+	bidiBN
+	bidiB
+	bidiS
+	bidiWS
+	bidiON
+	bidiLRE
+	bidiLRO
+	bidiRLE
+	bidiRLO
+	bidiPDF
+	bidiLRI
+	bidiRLI
+	bidiFSI
+	bidiPDI
+)
+
+func inRange(r, lo, hi rune) bool { return r >= lo && r <= hi }
+
+// bidiClassOf returns the bidi class of r.
+func bidiClassOf(r rune) bidiClass {
+	switch r {
+	case 0x202A:
+		return bidiLRE
+	case 0x202B:
+		return bidiRLE
+	case 0x202C:
+		return bidiPDF
+	case 0x202D:
+		return bidiLRO
+	case 0x202E:
+		return bidiRLO
+	case 0x2066:
+		return bidiLRI
+	case 0x2067:
+		return bidiRLI
+	case 0x2068:
+		return bidiFSI
+	case 0x2069:
+		return bidiPDI
+	case 0x200E:
+		return bidiL
+	case 0x200F:
+		return bidiR
+	case 0x061C:
+		return bidiAL
+	case '\n', '\r', 0x1C, 0x1D, 0x1E, 0x85, 0x2029:
+		return bidiB
+	case '\t', 0x0B, 0x1F:
+		return bidiS
+	case 0x0C, 0x2028:
+		return bidiWS
+	case '+', '-', 0x207A, 0x207B, 0x208A, 0x208B, 0x2212, 0xFB29, 0xFE62, 0xFE63, 0xFF0B, 0xFF0D:
+		return bidiES
+	case '#', '$', '%', 0xB0, 0xB1, 0x0609, 0x060A, 0x066A, 0x212E, 0x2213, 0xFE5F, 0xFE69, 0xFE6A, 0xFF03, 0xFF04, 0xFF05:
+		return bidiET
+	case ',', '.', '/', ':', 0xA0, 0x060C, 0x202F, 0x2044, 0xFE50, 0xFE52, 0xFE55, 0xFF0C, 0xFF0E, 0xFF0F, 0xFF1A:
+		return bidiCS
+	case 0xB2, 0xB3, 0xB9:
+		return bidiEN
+	case 0xAD, 0xFEFF:
+		return bidiBN
+	}
+	switch {
+	case inRange(r, '0', '9'), inRange(r, 0x06F0, 0x06F9), inRange(r, 0x2070, 0x2079),
+		inRange(r, 0x2080, 0x2089), inRange(r, 0xFF10, 0xFF19), inRange(r, 0x1D7CE, 0x1D7FF):
+		return bidiEN
+	case inRange(r, 0x0600, 0x0605), inRange(r, 0x0660, 0x0669), r == 0x066B, r == 0x066C,
+		r == 0x06DD, r == 0x08E2, inRange(r, 0x10E60, 0x10E7E):
+		return bidiAN
+	case unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r):
+		return bidiNSM
+	case inRange(r, 0x200B, 0x200D), inRange(r, 0x2060, 0x2065), unicode.Is(unicode.Cc, r):
+		return bidiBN
+	case unicode.Is(unicode.Zs, r):
+		return bidiWS
+	case unicode.Is(unicode.Sc, r), inRange(r, 0x2030, 0x2034):
+		return bidiET
+	case inRange(r, 0x0590, 0x05FF), inRange(r, 0x07C0, 0x085F), inRange(r, 0xFB1D, 0xFB4F),
+		inRange(r, 0x10800, 0x10FFF), inRange(r, 0x1E800, 0x1EDFF), inRange(r, 0x1EF00, 0x1EFFF):
+		return bidiR
+	case inRange(r, 0x0600, 0x07BF), inRange(r, 0x0860, 0x08FF), inRange(r, 0xFB50, 0xFDFF),
+		inRange(r, 0xFE70, 0xFEFE), inRange(r, 0x1EE00, 0x1EEFF):
+		return bidiAL
+	case unicode.IsLetter(r), unicode.Is(unicode.Mc, r), unicode.IsDigit(r), unicode.Is(unicode.Co, r):
+		return bidiL
+	}
+	return bidiON
+}
+
+func isIsolateInitiator(c bidiClass) bool { return c == bidiLRI || c == bidiRLI || c == bidiFSI }
+
+func isRemovedByX9(c bidiClass) bool {
+	return c == bidiRLE || c == bidiLRE || c == bidiRLO || c == bidiLRO || c == bidiPDF || c == bidiBN
+}
+
+// matchingPDIs returns, for each isolate initiator in classes, the index of
+// its matching PDI, or len(classes) if it has none. Other entries are -1.
+func matchingPDIs(classes []bidiClass) []int {
+	match := make([]int, len(classes))
+	var open []int
+	for i, c := range classes {
+		match[i] = -1
+		switch {
+		case isIsolateInitiator(c):
+			open = append(open, i)
+		case c == bidiPDI && len(open) > 0:
+			match[open[len(open)-1]] = i
+			open = open[:len(open)-1]
+		}
+	}
+	for _, i := range open {
+		match[i] = len(classes)
+	}
+	return match
+}
+
+// firstStrongRTL reports whether the first strong character in
+// classes[start:end], skipping isolates, is R or AL (rules P2 and P3).
+func firstStrongRTL(classes []bidiClass, match []int, start, end int) bool {
+	for i := start; i < end; i++ {
+		switch c := classes[i]; {
+		case c == bidiL:
+			return false
+		case c == bidiR || c == bidiAL:
+			return true
+		case isIsolateInitiator(c):
+			i = match[i]
+		}
+	}
+	return false
+}
+
+// bidiLevels returns the resolved embedding level of each rune of a single
+// paragraph whose base level is baseLevel, 0 for left-to-right and 1 for
+// right-to-left.
+func bidiLevels(runes []rune, baseLevel int) []int {
+	n := len(runes)
+	classes := make([]bidiClass, n)
+	for i, r := range runes {
+		classes[i] = bidiClassOf(r)
+	}
+	match := matchingPDIs(classes)
+	types := append([]bidiClass(nil), classes...)
+	levels := make([]int, n)
+
+	// Explicit levels and directions, rules X1 to X8.
+	const maxDepth = 125
+	type entry struct {
+		level    int
+		override bidiClass // bidiL, bidiR, or bidiON for none
+		isolate  bool
+	}
+	stack := []entry{{baseLevel, bidiON, false}}
+	overflowIsolates, overflowEmbeddings, validIsolates := 0, 0, 0
+	nextLevel := func(rtl bool) int {
+		level := stack[len(stack)-1].level
+		if rtl {
+			return (level + 1) | 1
+		}
+		return (level + 2) &^ 1
+	}
+	setFromTop := func(i int) {
+		top := stack[len(stack)-1]
+		levels[i] = top.level
+		if top.override != bidiON {
+			types[i] = top.override
+		}
+	}
+	for i, c := range classes {
+		switch c {
+		case bidiRLE, bidiLRE, bidiRLO, bidiLRO:
+			levels[i] = stack[len(stack)-1].level
+			level := nextLevel(c == bidiRLE || c == bidiRLO)
+			if level <= maxDepth && overflowIsolates == 0 && overflowEmbeddings == 0 {
+				override := bidiON
+				if c == bidiRLO {
+					override = bidiR
+				} else if c == bidiLRO {
+					override = bidiL
+				}
+				stack = append(stack, entry{level, override, false})
+			} else if overflowIsolates == 0 {
+				overflowEmbeddings++
+			}
+		case bidiRLI, bidiLRI, bidiFSI:
+			setFromTop(i)
+			rtl := c == bidiRLI
+			if c == bidiFSI {
+				rtl = firstStrongRTL(classes, match, i+1, match[i])
+			}
+			level := nextLevel(rtl)
+			if level <= maxDepth && overflowIsolates == 0 && overflowEmbeddings == 0 {
+				validIsolates++
+				stack = append(stack, entry{level, bidiON, true})
+			} else {
+				overflowIsolates++
+			}
+		case bidiPDI:
+			if overflowIsolates > 0 {
+				overflowIsolates--
+			} else if validIsolates > 0 {
+				overflowEmbeddings = 0
+				for !stack[len(stack)-1].isolate {
+					stack = stack[:len(stack)-1]
+				}
+				stack = stack[:len(stack)-1]
+				validIsolates--
+			}
+			setFromTop(i)
+		case bidiPDF:
+			levels[i] = stack[len(stack)-1].level
+			if overflowIsolates > 0 {
+			} else if overflowEmbeddings > 0 {
+				overflowEmbeddings--
+			} else if !stack[len(stack)-1].isolate && len(stack) > 1 {
+				stack = stack[:len(stack)-1]
+			}
+		case bidiB:
+			levels[i] = baseLevel
+		case bidiBN:
+			levels[i] = stack[len(stack)-1].level
+		default:
+			setFromTop(i)
+		}
+	}
+
+	// Rule X10: split the text, minus the characters removed by X9, into
+	// isolating run sequences and resolve each one separately.
+	var runs [][]int
+	prevLevel := -1
+	for i := 0; i < n; i++ {
+		if isRemovedByX9(classes[i]) {
+			continue
+		}
+		if levels[i] != prevLevel {
+			runs = append(runs, nil)
+			prevLevel = levels[i]
+		}
+		runs[len(runs)-1] = append(runs[len(runs)-1], i)
+	}
+	runStartingAt := map[int]int{}
+	for k, run := range runs {
+		runStartingAt[run[0]] = k
+	}
+	levelAround := func(i, step int) int {
+		for i += step; i >= 0 && i < n; i += step {
+			if !isRemovedByX9(classes[i]) {
+				return levels[i]
+			}
+		}
+		return baseLevel
+	}
+	for _, run := range runs {
+		if first := run[0]; classes[first] == bidiPDI && isMatchedPDI(match, first) {
+			continue
+		}
+		seq := append([]int(nil), run...)
+		for {
+			last := seq[len(seq)-1]
+			if !isIsolateInitiator(classes[last]) || match[last] >= n {
+				break
+			}
+			k, ok := runStartingAt[match[last]]
+			if !ok {
+				break
+			}
+			seq = append(seq, runs[k]...)
+		}
+		level := levels[seq[0]]
+		sos := maxInt(level, levelAround(seq[0], -1))
+		last := seq[len(seq)-1]
+		eos := baseLevel
+		if !isIsolateInitiator(classes[last]) {
+			eos = levelAround(last, 1)
+		}
+		eos = maxInt(level, eos)
+		resolveSequence(seq, types, levels, directionOf(sos), directionOf(eos))
+	}
+
+	// Rule L1: reset separators and trailing whitespace to the paragraph level.
+	trailing := true
+	for i := n - 1; i >= 0; i-- {
+		c := classes[i]
+		switch {
+		case c == bidiS || c == bidiB:
+			levels[i] = baseLevel
+			trailing = true
+		case trailing && (c == bidiWS || isIsolateInitiator(c) || c == bidiPDI || isRemovedByX9(c)):
+			levels[i] = baseLevel
+		default:
+			trailing = false
+		}
+	}
+	// Characters removed by X9 take the level of what precedes them, so that
+	// they don't split runs when reordering.
+	for i := range levels {
+		if isRemovedByX9(classes[i]) && i > 0 {
+			levels[i] = levels[i-1]
+		}
+	}
+	return levels
+}
+
+func isMatchedPDI(match []int, pdi int) bool {
+	for _, m := range match {
+		if m == pdi {
+			return true
+		}
+	}
+	return false
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
+
+func directionOf(level int) bidiClass {
+	if level%2 == 1 {
+		return bidiR
+	}
+	return bidiL
+}
+
+// resolveSequence applies the weak type rules W1 to W7, the neutral type
+// rules N1 and N2, and the implicit level rules I1 and I2 to the isolating
+// run sequence made of the given indices.
+func resolveSequence(seq []int, types []bidiClass, levels []int, sos, eos bidiClass) {
+	t := make([]bidiClass, len(seq))
+	for k, i := range seq {
+		t[k] = types[i]
+	}
+	// W1.
+	for k := range t {
+		if t[k] == bidiNSM {
+			switch {
+			case k == 0:
+				t[k] = sos
+			case isIsolateInitiator(t[k-1]) || t[k-1] == bidiPDI:
+				t[k] = bidiON
+			default:
+				t[k] = t[k-1]
+			}
+		}
+	}
+	// W2 and W3.
+	strong := sos
+	for k, c := range t {
+		switch c {
+		case bidiL, bidiR, bidiAL:
+			strong = c
+		case bidiEN:
+			if strong == bidiAL {
+				t[k] = bidiAN
+			}
+		}
+	}
+	for k := range t {
+		if t[k] == bidiAL {
+			t[k] = bidiR
+		}
+	}
+	// W4.
+	for k := 1; k+1 < len(t); k++ {
+		switch {
+		case t[k] == bidiES && t[k-1] == bidiEN && t[k+1] == bidiEN:
+			t[k] = bidiEN
+		case t[k] == bidiCS && t[k-1] == t[k+1] && (t[k-1] == bidiEN || t[k-1] == bidiAN):
+			t[k] = t[k-1]
+		}
+	}
+	// W5.
+	for k := 0; k < len(t); k++ {
+		if t[k] != bidiET {
+			continue
+		}
+		end := k
+		for end < len(t) && t[end] == bidiET {
+			end++
+		}
+		if k > 0 && t[k-1] == bidiEN || end < len(t) && t[end] == bidiEN {
+			for j := k; j < end; j++ {
+				t[j] = bidiEN
+			}
+		}
+		k = end - 1
+	}
+	// W6 and W7.
+	strong = sos
+	for k, c := range t {
+		switch c {
+		case bidiES, bidiET, bidiCS:
+			t[k] = bidiON
+		case bidiL, bidiR:
+			strong = c
+		case bidiEN:
+			if strong == bidiL {
+				t[k] = bidiL
+			}
+		}
+	}
+	// N1 and N2.
+	isNeutral := func(c bidiClass) bool {
+		return c == bidiB || c == bidiS || c == bidiWS || c == bidiON || isIsolateInitiator(c) || c == bidiPDI
+	}
+	strongOf := func(c bidiClass) bidiClass {
+		if c == bidiEN || c == bidiAN {
+			return bidiR
+		}
+		return c
+	}
+	for k := 0; k < len(t); k++ {
+		if !isNeutral(t[k]) {
+			continue
+		}
+		end := k
+		for end < len(t) && isNeutral(t[end]) {
+			end++
+		}
+		before, after := sos, eos
+		if k > 0 {
+			before = strongOf(t[k-1])
+		}
+		if end < len(t) {
+			after = strongOf(t[end])
+		}
+		dir := directionOf(levels[seq[k]])
+		if before == after {
+			dir = before
+		}
+		for j := k; j < end; j++ {
+			t[j] = dir
+		}
+		k = end - 1
+	}
+	// I1 and I2.
+	for k, i := range seq {
+		types[i] = t[k]
+		switch {
+		case levels[i]%2 == 0 && t[k] == bidiR:
+			levels[i]++
+		case levels[i]%2 == 0 && (t[k] == bidiAN || t[k] == bidiEN):
+			levels[i] += 2
+		case levels[i]%2 == 1 && (t[k] == bidiL || t[k] == bidiEN || t[k] == bidiAN):
+			levels[i]++
+		}
+	}
+}
+
+// visualOrder returns the logical indices of characters with the given
+// resolved levels in left-to-right display order (rule L2).
+func visualOrder(levels []int) []int {
+	order := make([]int, len(levels))
+	highest, lowestOdd := 0, 1<<30
+	for i, l := range levels {
+		order[i] = i
+		if l > highest {
+			highest = l
+		}
+		if l%2 == 1 && l < lowestOdd {
+			lowestOdd = l
+		}
+	}
+	for level := highest; level >= lowestOdd; level-- {
+		for i := 0; i < len(order); i++ {
+			if levels[order[i]] < level {
+				continue
+			}
+			j := i
+			for j < len(order) && levels[order[j]] >= level {
+				j++
+			}
+			for a, b := i, j-1; a < b; a, b = a+1, b-1 {
+				order[a], order[b] = order[b], order[a]
+			}
+			i = j
+		}
+	}
+	return order
+}
+
+// bidiMirrors maps characters to their mirrored forms, used for characters
+// resolved to right-to-left levels (rule L4).
+var bidiMirrors = map[rune]rune{
+	'(': ')', ')': '(', '<': '>', '>': '<', '[': ']', ']': '[', '{': '}', '}': '{',
+	'«': '»', '»': '«', '‹': '›', '›': '‹', '⁅': '⁆', '⁆': '⁅', '⁽': '⁾', '⁾': '⁽',
+	'₍': '₎', '₎': '₍', '≤': '≥', '≥': '≤', '≪': '≫', '≫': '≪', '⟨': '⟩', '⟩': '⟨',
+	'〈': '〉', '〉': '〈', '《': '》', '》': '《', '「': '」', '」': '「', '『': '』', '』': '『',
+	'【': '】', '】': '【', '（': '）', '）': '（', '［': '］', '］': '［', '｛': '｝', '｝': '｛',
+}
@@ -0,0 +1,198 @@
+package filmore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+// binaryVersion is the version of the encoding written by EncodeBinary.
+const binaryVersion = 1
+
+// Kinds of op in the binary encoding.
+const (
+	binaryMoveTo = iota
+	binaryLineTo
+	binaryClosePath
+	binaryQuadTo
+	binaryCubeTo
+)
+
+// EncodeBinary returns p in a compact, versioned binary encoding, several
+// times smaller than its JSON, for caching precomputed paths and sending them
+// over the network. DecodeBinary reads it back. The encoding is:
+//
+//	"FMB" version             magic and version byte, currently 1
+//	width height              float32
+//	n                         uvarint number of ops, then n ops
+//	kind coordinates...       kind byte, then float32 deltas
+//	m                         uvarint number of sources, then m sources
+//	rune glyph start length   uvarint each
+//
+// Kinds are 0 for MoveTo, 1 for LineTo, 2 for ClosePath, 3 for QuadCurveTo
+// and 4 for CubicCurveTo. Their coordinates, control points first, are each
+// given relative to the end of the op before, or the origin for the first,
+// and ClosePath has none. Numbers are little-endian. Coordinates are rounded
+// to float32 precision, without the rounding adding up along a path.
+func (p *TextPath) EncodeBinary() []byte {
+	buf := []byte{'F', 'M', 'B', binaryVersion}
+	var word [4]byte
+	putFloat := func(v float32) {
+		binary.LittleEndian.PutUint32(word[:], math.Float32bits(v))
+		buf = append(buf, word[:]...)
+	}
+	var varint [binary.MaxVarintLen64]byte
+	putUvarint := func(v int) {
+		buf = append(buf, varint[:binary.PutUvarint(varint[:], uint64(v))]...)
+	}
+	putFloat(float32(p.Width))
+	putFloat(float32(p.Height))
+	putUvarint(len(p.PathOps))
+	// px, py and mx, my are the current point and the start of the contour as
+	// the decoder will see them, so that each delta makes up for the
+	// rounding of those before.
+	var px, py, mx, my float64
+	point := func(x, y float64) (float64, float64) {
+		dx, dy := float32(x-px), float32(y-py)
+		putFloat(dx)
+		putFloat(dy)
+		return px + float64(dx), py + float64(dy)
+	}
+	for _, op := range p.PathOps {
+		switch op := op.(type) {
+		case MoveTo:
+			buf = append(buf, binaryMoveTo)
+			px, py = point(op.x, op.y)
+			mx, my = px, py
+		case LineTo:
+			buf = append(buf, binaryLineTo)
+			px, py = point(op.x, op.y)
+		case ClosePath:
+			buf = append(buf, binaryClosePath)
+			px, py = mx, my
+		case QuadCurveTo:
+			buf = append(buf, binaryQuadTo)
+			point(op.cx, op.cy)
+			px, py = point(op.x, op.y)
+		case CubicCurveTo:
+			buf = append(buf, binaryCubeTo)
+			point(op.cx1, op.cy1)
+			point(op.cx2, op.cy2)
+			px, py = point(op.x, op.y)
+		}
+	}
+	putUvarint(len(p.Sources))
+	for _, s := range p.Sources {
+		putUvarint(s.RuneIndex)
+		putUvarint(int(s.Glyph))
+		putUvarint(s.Start)
+		putUvarint(s.End - s.Start)
+	}
+	return buf
+}
+
+// DecodeBinary sets p to the path encoded in data by EncodeBinary. It fails
+// if data is not such an encoding or is of a later version.
+func (p *TextPath) DecodeBinary(data []byte) error {
+	if len(data) < 4 || string(data[:3]) != "FMB" {
+		return fmt.Errorf("filmore: not a binary path")
+	}
+	if data[3] != binaryVersion {
+		return fmt.Errorf("filmore: binary path version %d is not supported", data[3])
+	}
+	data = data[4:]
+	errTruncated := fmt.Errorf("filmore: binary path is truncated")
+	getFloat := func() (float64, bool) {
+		if len(data) < 4 {
+			return 0, false
+		}
+		v := math.Float32frombits(binary.LittleEndian.Uint32(data))
+		data = data[4:]
+		return float64(v), true
+	}
+	getUvarint := func() (int, bool) {
+		v, n := binary.Uvarint(data)
+		if n <= 0 || v > math.MaxInt32 {
+			return 0, false
+		}
+		data = data[n:]
+		return int(v), true
+	}
+	width, ok1 := getFloat()
+	height, ok2 := getFloat()
+	n, ok3 := getUvarint()
+	if !ok1 || !ok2 || !ok3 {
+		return errTruncated
+	}
+	path := TextPath{Width: width, Height: height}
+	var px, py float64
+	point := func() (float64, float64, bool) {
+		dx, ok1 := getFloat()
+		dy, ok2 := getFloat()
+		return px + dx, py + dy, ok1 && ok2
+	}
+	for i := 0; i < n; i++ {
+		if len(data) == 0 {
+			return errTruncated
+		}
+		kind := data[0]
+		data = data[1:]
+		var x, y, cx1, cy1, cx2, cy2 float64
+		ok := true
+		switch kind {
+		case binaryMoveTo, binaryLineTo:
+			x, y, ok = point()
+		case binaryClosePath:
+		case binaryQuadTo:
+			var ok2 bool
+			cx1, cy1, ok = point()
+			x, y, ok2 = point()
+			ok = ok && ok2
+		case binaryCubeTo:
+			var ok2, ok3 bool
+			cx1, cy1, ok = point()
+			cx2, cy2, ok2 = point()
+			x, y, ok3 = point()
+			ok = ok && ok2 && ok3
+		default:
+			return fmt.Errorf("filmore: binary path: op %d has unknown kind %d", i, kind)
+		}
+		if !ok {
+			return errTruncated
+		}
+		switch kind {
+		case binaryMoveTo:
+			path.MoveTo(x, y)
+		case binaryLineTo:
+			path.LineTo(x, y)
+		case binaryClosePath:
+			path.ClosePath()
+		case binaryQuadTo:
+			path.QuadCurveTo(x, y, cx1, cy1)
+		case binaryCubeTo:
+			path.CubicCurveTo(x, y, cx1, cy1, cx2, cy2)
+		}
+		last := path.PathOps[len(path.PathOps)-1]
+		px, py = last.X(), last.Y()
+	}
+	m, ok := getUvarint()
+	if !ok {
+		return errTruncated
+	}
+	for i := 0; i < m; i++ {
+		var v [4]int
+		for j := range v {
+			if v[j], ok = getUvarint(); !ok {
+				return errTruncated
+			}
+		}
+		if v[2]+v[3] > len(path.PathOps) {
+			return fmt.Errorf("filmore: binary path: source out of range")
+		}
+		path.Sources = append(path.Sources, GlyphSource{v[0], truetype.Index(v[1]), v[2], v[2] + v[3]})
+	}
+	*p = path
+	return nil
+}
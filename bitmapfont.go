@@ -0,0 +1,290 @@
+package filmore
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/hex"
+	"fmt"
+	"image"
+	"strconv"
+	"strings"
+)
+
+// A BitmapFont is a font whose glyphs are drawn pixel by pixel, such as the
+// classic X11 fonts in BDF and PCF format. Its text paths outline the glyphs'
+// pixels, or trace smoother shapes from them.
+type BitmapFont struct {
+	// Scale is the size of a pixel of the font in the paths it creates. It
+	// is 1 for newly loaded fonts.
+	Scale float64
+	// Smoothing is passed to TraceBitmap for each glyph. At 0, the default,
+	// glyphs are outlined exactly as the squares of their pixels.
+	Smoothing float64
+
+	glyphs       map[rune]bitmapGlyph
+	defaultGlyph rune
+}
+
+type bitmapGlyph struct {
+	// bitmap has its origin at the glyph's origin on the baseline, with y
+	// pointing down.
+	bitmap  *image.Alpha
+	advance int
+}
+
+// CreateTextPath creates a path from the string s at x, y, with the origin of
+// the first glyph on the baseline at x, y. Runes the font has no glyph for
+// are drawn with its default glyph if it has one, and skipped otherwise.
+func (f *BitmapFont) CreateTextPath(s string, x, y float64) TextPath {
+	result := TextPath{}
+	for _, r := range s {
+		g, ok := f.glyphs[r]
+		if !ok {
+			if g, ok = f.glyphs[f.defaultGlyph]; !ok {
+				continue
+			}
+		}
+		b := g.bitmap.Bounds()
+		p := TraceBitmap(g.bitmap, x+float64(b.Min.X)*f.Scale, y+float64(b.Min.Y)*f.Scale, f.Scale, f.Smoothing)
+		result.PathOps = append(result.PathOps, p.PathOps...)
+		advance := float64(g.advance) * f.Scale
+		x += advance
+		result.Width += advance
+	}
+	return result
+}
+
+func newBitmapFont() *BitmapFont {
+	return &BitmapFont{Scale: 1, glyphs: map[rune]bitmapGlyph{}, defaultGlyph: -1}
+}
+
+// NewBDFFont loads a font in the Glyph Bitmap Distribution Format. Glyph
+// encodings are taken to be Unicode code points, which holds for fonts in the
+// ISO10646-1 and ISO8859-1 character sets.
+func NewBDFFont(fontData []byte) (*BitmapFont, error) {
+	f := newBitmapFont()
+	scanner := bufio.NewScanner(bytes.NewReader(fontData))
+	line := 0
+	errorf := func(format string, args ...interface{}) error {
+		return fmt.Errorf("filmore: BDF line %d: %s", line, fmt.Sprintf(format, args...))
+	}
+	var (
+		encoding   int
+		advance    int
+		w, h       int
+		xoff, yoff int
+		bitmap     *image.Alpha
+		row        = -1
+	)
+	for scanner.Scan() {
+		line++
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		ints := func(n int) ([]int, error) {
+			if len(fields) < n+1 {
+				return nil, errorf("%s needs %d values", fields[0], n)
+			}
+			v := make([]int, n)
+			for i := range v {
+				var err error
+				if v[i], err = strconv.Atoi(fields[i+1]); err != nil {
+					return nil, errorf("%v", err)
+				}
+			}
+			return v, nil
+		}
+		if row >= 0 {
+			if fields[0] == "ENDCHAR" {
+				if encoding >= 0 {
+					f.glyphs[rune(encoding)] = bitmapGlyph{bitmap, advance}
+				}
+				row = -1
+				continue
+			}
+			bits, err := hex.DecodeString(fields[0])
+			if err != nil {
+				return nil, errorf("%v", err)
+			}
+			for px := 0; px < w && px/8 < len(bits) && row < h; px++ {
+				if bits[px/8]&(0x80>>uint(px%8)) != 0 {
+					bitmap.Pix[row*bitmap.Stride+px] = 0xff
+				}
+			}
+			row++
+			continue
+		}
+		switch fields[0] {
+		case "DEFAULT_CHAR":
+			v, err := ints(1)
+			if err != nil {
+				return nil, err
+			}
+			f.defaultGlyph = rune(v[0])
+		case "STARTCHAR":
+			encoding, advance, w, h, xoff, yoff = -1, 0, 0, 0, 0, 0
+		case "ENCODING":
+			v, err := ints(1)
+			if err != nil {
+				return nil, err
+			}
+			encoding = v[0]
+		case "DWIDTH":
+			v, err := ints(2)
+			if err != nil {
+				return nil, err
+			}
+			advance = v[0]
+		case "BBX":
+			v, err := ints(4)
+			if err != nil {
+				return nil, err
+			}
+			w, h, xoff, yoff = v[0], v[1], v[2], v[3]
+		case "BITMAP":
+			bitmap = image.NewAlpha(image.Rect(xoff, -yoff-h, xoff+w, -yoff))
+			row = 0
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(f.glyphs) == 0 {
+		return nil, fmt.Errorf("filmore: no glyphs in BDF font")
+	}
+	return f, nil
+}
+
+// PCF table types and format flags.
+const (
+	pcfMetrics        = 1 << 2
+	pcfBitmaps        = 1 << 3
+	pcfBDFEncodings   = 1 << 5
+	pcfCompressed     = 0x100
+	pcfByteOrderMSB   = 1 << 2
+	pcfBitOrderMSB    = 1 << 3
+	pcfGlyphPadMask   = 3
+	pcfScanUnitMask   = 3 << 4
+	pcfScanUnitOffset = 4
+)
+
+// pcfTable is a table of a PCF font, with readers that honour its byte order.
+type pcfTable struct {
+	data   []byte
+	format int
+}
+
+func (t pcfTable) u16(i int) int {
+	if t.format&pcfByteOrderMSB != 0 {
+		return u16(t.data, i)
+	}
+	return u8(t.data, i) | u8(t.data, i+1)<<8
+}
+
+func (t pcfTable) i16(i int) int {
+	return int(int16(t.u16(i)))
+}
+
+func (t pcfTable) i32(i int) int {
+	if t.format&pcfByteOrderMSB != 0 {
+		return int(int32(u32(t.data, i)))
+	}
+	return int(int32(t.u16(i) | t.u16(i+2)<<16))
+}
+
+// NewPCFFont loads a font in the X11 Portable Compiled Format. Glyph
+// encodings are taken to be Unicode code points, which holds for fonts in the
+// ISO10646-1 and ISO8859-1 character sets.
+func NewPCFFont(fontData []byte) (*BitmapFont, error) {
+	if len(fontData) < 8 || string(fontData[:4]) != "\x01fcp" {
+		return nil, fmt.Errorf("filmore: not a PCF font")
+	}
+	toc := pcfTable{fontData, 0}
+	tables := map[int]pcfTable{}
+	for i, n := 0, toc.i32(4); i < n; i++ {
+		rec := 8 + 16*i
+		typ, offset, size := toc.i32(rec), toc.i32(rec+12), toc.i32(rec+8)
+		if offset < 0 || size < 4 || offset+size > len(fontData) {
+			return nil, fmt.Errorf("filmore: PCF table %#x out of range", typ)
+		}
+		data := fontData[offset : offset+size]
+		tables[typ] = pcfTable{data, toc.i32(offset)}
+	}
+	metrics, bitmaps, encodings := tables[pcfMetrics], tables[pcfBitmaps], tables[pcfBDFEncodings]
+	if metrics.data == nil || bitmaps.data == nil || encodings.data == nil {
+		return nil, fmt.Errorf("filmore: PCF font lacks metrics, bitmaps or encodings")
+	}
+
+	type metric struct{ left, right, width, ascent, descent int }
+	var ms []metric
+	if metrics.format&pcfCompressed != 0 {
+		for i, n := 0, metrics.i16(4); i < n; i++ {
+			rec := 6 + 5*i
+			v := func(j int) int { return u8(metrics.data, rec+j) - 0x80 }
+			ms = append(ms, metric{v(0), v(1), v(2), v(3), v(4)})
+		}
+	} else {
+		for i, n := 0, metrics.i32(4); i < n; i++ {
+			rec := 8 + 12*i
+			ms = append(ms, metric{metrics.i16(rec), metrics.i16(rec + 2), metrics.i16(rec + 4), metrics.i16(rec + 6), metrics.i16(rec + 8)})
+		}
+	}
+
+	count := bitmaps.i32(4)
+	pad := 1 << uint(bitmaps.format&pcfGlyphPadMask)
+	unit := 1 << uint((bitmaps.format&pcfScanUnitMask)>>pcfScanUnitOffset)
+	bits := sub(bitmaps.data, 8+4*count+16)
+	glyphBitmap := func(index int) (*image.Alpha, int) {
+		if index < 0 || index >= count || index >= len(ms) {
+			return nil, 0
+		}
+		m := ms[index]
+		w, h := m.right-m.left, m.ascent+m.descent
+		if w < 0 || h < 0 {
+			return nil, 0
+		}
+		stride := (w + 8*pad - 1) / (8 * pad) * pad
+		src := sub(bits, bitmaps.i32(8+4*index))
+		img := image.NewAlpha(image.Rect(m.left, -m.ascent, m.right, m.descent))
+		for y := 0; y < h; y++ {
+			for x := 0; x < w; x++ {
+				// Bytes are stored in scan units in the table's byte order,
+				// and bits in each byte in the table's bit order.
+				i := y*stride + x/8
+				if (bitmaps.format&pcfByteOrderMSB != 0) != (bitmaps.format&pcfBitOrderMSB != 0) {
+					i = i - i%unit + unit - 1 - i%unit
+				}
+				bit := uint(x % 8)
+				if bitmaps.format&pcfBitOrderMSB != 0 {
+					bit = 7 - bit
+				}
+				if u8(src, i)&(1<<bit) != 0 {
+					img.Pix[y*img.Stride+x] = 0xff
+				}
+			}
+		}
+		return img, m.width
+	}
+
+	f := newBitmapFont()
+	minByte2, maxByte2 := encodings.i16(4), encodings.i16(6)
+	minByte1, maxByte1 := encodings.i16(8), encodings.i16(10)
+	f.defaultGlyph = rune(encodings.i16(12))
+	perRow := maxByte2 - minByte2 + 1
+	for b1 := minByte1; b1 <= maxByte1; b1++ {
+		for b2 := minByte2; b2 <= maxByte2; b2++ {
+			index := encodings.u16(14 + 2*((b1-minByte1)*perRow+b2-minByte2))
+			if index == 0xffff {
+				continue
+			}
+			if img, advance := glyphBitmap(index); img != nil {
+				f.glyphs[rune(b1<<8|b2)] = bitmapGlyph{img, advance}
+			}
+		}
+	}
+	if len(f.glyphs) == 0 {
+		return nil, fmt.Errorf("filmore: no glyphs in PCF font")
+	}
+	return f, nil
+}
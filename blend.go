@@ -0,0 +1,79 @@
+package filmore
+
+import (
+	"fmt"
+	"math"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+// BlendTextPath lays s out at x, y with outlines interpolated between the
+// fonts a and b, in the manner of a multiple master font: t = 0 gives a's
+// glyphs, t = 1 gives b's, and values in between give a blend of their
+// outlines, advances and kerning. Values outside [0, 1] extrapolate.
+//
+// Blending needs compatible fonts, such as two weights drawn from the same
+// masters: each pair of glyphs must have the same contours, with the same
+// number of points and the same on-curve and off-curve points. If a pair is
+// not compatible, BlendTextPath returns the path built so far and an error
+// naming the rune.
+func BlendTextPath(a, b *Font, t float64, s string, x, y float64) (TextPath, error) {
+	result := TextPath{}
+	var prevA, prevB truetype.Index
+	for i, r := range []rune(s) {
+		ia, ib := a.font.Index(r), b.font.Index(r)
+		if i > 0 {
+			kern := lerp(fUnitsToFloat64(a.font.Kerning(a.scale, prevA, ia)), fUnitsToFloat64(b.font.Kerning(b.scale, prevB, ib)), t)
+			x += kern
+			result.Width += kern
+		}
+		points, ends, err := blendGlyphs(a, ia, b, ib, t)
+		if err != nil {
+			return result, fmt.Errorf("filmore: cannot blend %q: %v", r, err)
+		}
+		e0 := 0
+		for _, e1 := range ends {
+			result.appendContour(points[e0:e1], x, y)
+			e0 = e1
+		}
+		advance := lerp(fUnitsToFloat64(a.font.HMetric(a.scale, ia).AdvanceWidth), fUnitsToFloat64(b.font.HMetric(b.scale, ib).AdvanceWidth), t)
+		x += advance
+		result.Width += advance
+		prevA, prevB = ia, ib
+	}
+	return result, nil
+}
+
+// blendGlyphs returns the points of glyph ga of a interpolated toward those of
+// glyph gb of b, along with the contour ends.
+func blendGlyphs(a *Font, ga truetype.Index, b *Font, gb truetype.Index, t float64) ([]truetype.Point, []int, error) {
+	if err := a.loadGlyph(ga); err != nil {
+		return nil, nil, err
+	}
+	points := append([]truetype.Point(nil), a.glyphBuf.Point...)
+	ends := append([]int(nil), a.glyphBuf.End...)
+	if err := b.loadGlyph(gb); err != nil {
+		return nil, nil, err
+	}
+	if len(b.glyphBuf.Point) != len(points) || len(b.glyphBuf.End) != len(ends) {
+		return nil, nil, fmt.Errorf("glyphs have different numbers of points or contours")
+	}
+	for i, e := range ends {
+		if b.glyphBuf.End[i] != e {
+			return nil, nil, fmt.Errorf("contour %d has a different number of points", i)
+		}
+	}
+	for i := range points {
+		q := b.glyphBuf.Point[i]
+		if points[i].Flags&0x01 != q.Flags&0x01 {
+			return nil, nil, fmt.Errorf("point %d is on the curve in only one glyph", i)
+		}
+		points[i].X = int32(math.Floor(lerp(float64(points[i].X), float64(q.X), t) + 0.5))
+		points[i].Y = int32(math.Floor(lerp(float64(points[i].Y), float64(q.Y), t) + 0.5))
+	}
+	return points, ends, nil
+}
+
+func lerp(a, b, t float64) float64 {
+	return a + (b-a)*t
+}
@@ -0,0 +1,17 @@
+package filmore
+
+// emboldenAdvances widens the advance of each glyph with one by twice
+// strength, to make room for outlines moved outward by strength on both
+// sides, and moves the marks attached to it back so that they stay over it.
+func emboldenAdvances(glyphs []fontGlyph, strength float64) {
+	if strength == 0 {
+		return
+	}
+	for i := range glyphs {
+		if glyphs[i].XAdvance != 0 {
+			glyphs[i].XAdvance += 2 * strength
+		} else if i > 0 {
+			glyphs[i].XOffset -= 2 * strength
+		}
+	}
+}
@@ -0,0 +1,513 @@
+package filmore
+
+import (
+	"math"
+	"sort"
+)
+
+// A BooleanOp says how Combine merges the areas of two paths.
+type BooleanOp int
+
+const (
+	// BooleanUnion keeps the area covered by either path.
+	BooleanUnion BooleanOp = iota
+	// BooleanIntersect keeps the area covered by both paths.
+	BooleanIntersect
+	// BooleanDifference keeps the area of the first path not covered by the
+	// second.
+	BooleanDifference
+	// BooleanXor keeps the area covered by exactly one of the paths.
+	BooleanXor
+)
+
+func (op BooleanOp) apply(a, b bool) bool {
+	switch op {
+	case BooleanUnion:
+		return a || b
+	case BooleanIntersect:
+		return a && b
+	case BooleanDifference:
+		return a && !b
+	}
+	return a != b
+}
+
+// Combine returns the outline of the area obtained by applying op to the
+// areas filled by p and q under the non-zero winding rule. Either path may be
+// text or any other shape. The result has no overlapping or self-intersecting
+// contours; outer contours and holes wind in opposite directions, with the
+// filled area on the left of each segment as in glyph outlines. Curves are
+// flattened to within a small fraction of a pixel, so the result contains
+// only straight segments. Its Width is that of p.
+func (p *TextPath) Combine(op BooleanOp, q *TextPath) TextPath {
+	var polys []polygon
+	for _, pt := range p.Flatten(defaultTolerance) {
+		polys = append(polys, polygon{pt, 0})
+	}
+	if q != nil {
+		for _, pt := range q.Flatten(defaultTolerance) {
+			polys = append(polys, polygon{pt, 1})
+		}
+	}
+	fill := func(w [2]int) bool { return op.apply(w[0] != 0, w[1] != 0) }
+	result := pathFromLoops(booleanLoops(polys, fill))
+	result.Width = p.Width
+	return result
+}
+
+// Union returns the outline of the area p fills, with overlapping contours
+// merged, such as where tightly tracked or script glyphs run into each other.
+// Filled with the even-odd rule or cut with a laser, the result looks as p
+// does filled with the non-zero rule. See Combine.
+func (p *TextPath) Union() TextPath {
+	return p.Combine(BooleanUnion, nil)
+}
+
+// Intersect returns the part of p that lies inside q, such as text clipped to
+// a frame shape. See Combine.
+func (p *TextPath) Intersect(q *TextPath) TextPath {
+	return p.Combine(BooleanIntersect, q)
+}
+
+// Subtract returns the part of p that lies outside q, such as a shape with
+// text-shaped holes when q is text. See Combine.
+func (p *TextPath) Subtract(q *TextPath) TextPath {
+	return p.Combine(BooleanDifference, q)
+}
+
+// IntersectRect returns the part of p that lies inside r, such as text
+// clipped to a panel or a page. See Combine.
+func (p *TextPath) IntersectRect(r Rect) TextPath {
+	q := rectPath(r.MinX, r.MinY, r.MaxX, r.MaxY)
+	return p.Intersect(&q)
+}
+
+// SubtractRect returns the part of p that lies outside r, such as text with a
+// knocked-out strip for a banner to run through. See Combine.
+func (p *TextPath) SubtractRect(r Rect) TextPath {
+	q := rectPath(r.MinX, r.MinY, r.MaxX, r.MaxY)
+	return p.Subtract(&q)
+}
+
+// polygon is a closed polyline belonging to one of the operands of a boolean
+// operation.
+type polygon struct {
+	points []Point
+	owner  int
+}
+
+type edge struct {
+	a, b  Point
+	owner int
+}
+
+func cross(ax, ay, bx, by float64) float64 { return ax*by - ay*bx }
+
+// pointPool snaps points that are within eps of one another to a single
+// representative, so that edges computed separately share exact endpoints.
+type pointPool struct {
+	eps   float64
+	cells map[[2]int64][]Point
+}
+
+func newPointPool(eps float64) *pointPool {
+	return &pointPool{eps, map[[2]int64][]Point{}}
+}
+
+func (pp *pointPool) snap(p Point) Point {
+	cx, cy := int64(math.Floor(p.X/pp.eps)), int64(math.Floor(p.Y/pp.eps))
+	for dx := int64(-1); dx <= 1; dx++ {
+		for dy := int64(-1); dy <= 1; dy++ {
+			for _, q := range pp.cells[[2]int64{cx + dx, cy + dy}] {
+				if math.Abs(q.X-p.X) <= pp.eps && math.Abs(q.Y-p.Y) <= pp.eps {
+					return q
+				}
+			}
+		}
+	}
+	key := [2]int64{cx, cy}
+	pp.cells[key] = append(pp.cells[key], p)
+	return p
+}
+
+// splitEdges splits the edges of polys wherever they cross or touch one
+// another, so that afterwards edges meet only at their endpoints.
+func splitEdges(polys []polygon, eps float64) []edge {
+	pool := newPointPool(eps)
+	var edges []edge
+	for _, poly := range polys {
+		pts := poly.points
+		n := len(pts)
+		if n > 1 && pts[0] == pts[n-1] {
+			n--
+		}
+		for i := 0; i < n; i++ {
+			a, b := pool.snap(pts[i]), pool.snap(pts[(i+1)%n])
+			if a != b {
+				edges = append(edges, edge{a, b, poly.owner})
+			}
+		}
+	}
+	splits := make([][]Point, len(edges))
+	// Sweep in x so that only edges with overlapping extents are compared.
+	order := make([]int, len(edges))
+	for i := range order {
+		order[i] = i
+	}
+	minX := func(e edge) float64 { return math.Min(e.a.X, e.b.X) }
+	maxX := func(e edge) float64 { return math.Max(e.a.X, e.b.X) }
+	sort.Slice(order, func(i, j int) bool { return minX(edges[order[i]]) < minX(edges[order[j]]) })
+	for oi, i := range order {
+		e := edges[i]
+		for _, j := range order[oi+1:] {
+			f := edges[j]
+			if minX(f) > maxX(e)+eps {
+				break
+			}
+			if math.Min(f.a.Y, f.b.Y) > math.Max(e.a.Y, e.b.Y)+eps ||
+				math.Min(e.a.Y, e.b.Y) > math.Max(f.a.Y, f.b.Y)+eps {
+				continue
+			}
+			for _, pt := range intersectSegments(e.a, e.b, f.a, f.b, eps) {
+				pt = pool.snap(pt)
+				splits[i] = append(splits[i], pt)
+				splits[j] = append(splits[j], pt)
+			}
+		}
+	}
+	var result []edge
+	for i, e := range edges {
+		pts := splits[i]
+		dx, dy := e.b.X-e.a.X, e.b.Y-e.a.Y
+		sort.Slice(pts, func(k, l int) bool {
+			return (pts[k].X-e.a.X)*dx+(pts[k].Y-e.a.Y)*dy < (pts[l].X-e.a.X)*dx+(pts[l].Y-e.a.Y)*dy
+		})
+		prev := e.a
+		for _, pt := range append(pts, e.b) {
+			if pt != prev {
+				result = append(result, edge{prev, pt, e.owner})
+				prev = pt
+			}
+		}
+	}
+	return result
+}
+
+// intersectSegments returns the points where segment ab meets segment cd,
+// other than shared endpoints. Where the segments are collinear and overlap,
+// it returns the endpoints of each that lie inside the other.
+func intersectSegments(a, b, c, d Point, eps float64) []Point {
+	rx, ry := b.X-a.X, b.Y-a.Y
+	sx, sy := d.X-c.X, d.Y-c.Y
+	rl, sl := math.Hypot(rx, ry), math.Hypot(sx, sy)
+	denom := cross(rx, ry, sx, sy)
+	// onSegment reports whether p lies on segment ab, not at its ends.
+	onSegment := func(p, a, b Point, l float64) bool {
+		dx, dy := b.X-a.X, b.Y-a.Y
+		if math.Abs(cross(dx, dy, p.X-a.X, p.Y-a.Y))/l > eps {
+			return false
+		}
+		t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / (l * l)
+		return t*l > eps && (1-t)*l > eps
+	}
+	var result []Point
+	if math.Abs(denom) <= 1e-12*rl*sl {
+		// Parallel: only collinear overlaps matter.
+		for _, p := range []Point{c, d} {
+			if onSegment(p, a, b, rl) {
+				result = append(result, p)
+			}
+		}
+		for _, p := range []Point{a, b} {
+			if onSegment(p, c, d, sl) {
+				result = append(result, p)
+			}
+		}
+		return result
+	}
+	t := cross(c.X-a.X, c.Y-a.Y, sx, sy) / denom
+	u := cross(c.X-a.X, c.Y-a.Y, rx, ry) / denom
+	if t*rl < -eps || (t-1)*rl > eps || u*sl < -eps || (u-1)*sl > eps {
+		return nil
+	}
+	// Prefer existing endpoints, so that T-junctions meet exactly.
+	switch {
+	case t*rl <= eps || (1-t)*rl <= eps:
+		if u*sl <= eps || (1-u)*sl <= eps {
+			return nil
+		}
+		if t*rl <= eps {
+			return []Point{a}
+		}
+		return []Point{b}
+	case u*sl <= eps:
+		return []Point{c}
+	case (1-u)*sl <= eps:
+		return []Point{d}
+	}
+	return []Point{{a.X + t*rx, a.Y + t*ry}}
+}
+
+// windingIndex finds the winding numbers on either side of edges that meet
+// one another only at endpoints or by coinciding, casting each ray only
+// against the edges in the band of the plane it runs along.
+type windingIndex struct {
+	edges []edge
+	// rows holds the edges spanning each horizontal band, and cols those
+	// spanning each vertical band.
+	rows, cols bands
+	// along counts, for each owner, the edges running from one point to
+	// another.
+	along map[[2]Point][2]int
+}
+
+func newWindingIndex(edges []edge) *windingIndex {
+	w := &windingIndex{edges: edges, along: map[[2]Point][2]int{}}
+	var lo, hi Point
+	for i, e := range edges {
+		if i == 0 {
+			lo, hi = e.a, e.a
+		}
+		for _, p := range []Point{e.a, e.b} {
+			lo = Point{math.Min(lo.X, p.X), math.Min(lo.Y, p.Y)}
+			hi = Point{math.Max(hi.X, p.X), math.Max(hi.Y, p.Y)}
+		}
+		k := [2]Point{e.a, e.b}
+		n := w.along[k]
+		n[e.owner]++
+		w.along[k] = n
+	}
+	count := int(2*math.Sqrt(float64(len(edges)))) + 1
+	w.rows = newBands(lo.Y, hi.Y, count)
+	w.cols = newBands(lo.X, hi.X, count)
+	for i, e := range edges {
+		// Edges along a band's axis never cross rays cast along it.
+		if e.a.Y != e.b.Y {
+			w.rows.add(i, e.a.Y, e.b.Y)
+		}
+		if e.a.X != e.b.X {
+			w.cols.add(i, e.a.X, e.b.X)
+		}
+	}
+	return w
+}
+
+// across returns, for each owner, the winding numbers of the areas just to
+// the left and just to the right of edges[i].
+func (w *windingIndex) across(i int) (left, right [2]int) {
+	e := w.edges[i]
+	m := Point{(e.a.X + e.b.X) / 2, (e.a.Y + e.b.Y) / 2}
+	vx, vy := e.b.X-e.a.X, e.b.Y-e.a.Y
+	coincident := func(f edge) bool { return f.a == e.a && f.b == e.b || f.a == e.b && f.b == e.a }
+	// Cast a ray from the middle of e along whichever axis crosses it more
+	// steeply. Apart from e and the edges coinciding with it, which it
+	// doesn't cross, it measures the winding on one side of e. Coming in
+	// from infinity, crossing an edge from its right to its left raises the
+	// winding number.
+	var side [2]int
+	var onRight bool
+	if math.Abs(vy) >= math.Abs(vx) {
+		// To the right, in x.
+		for _, j := range w.rows.at(m.Y) {
+			f := w.edges[j]
+			if (f.a.Y > m.Y) == (f.b.Y > m.Y) || coincident(f) {
+				continue
+			}
+			if f.a.X+(m.Y-f.a.Y)*(f.b.X-f.a.X)/(f.b.Y-f.a.Y) <= m.X {
+				continue
+			}
+			if f.b.Y > f.a.Y {
+				side[f.owner]++
+			} else {
+				side[f.owner]--
+			}
+		}
+		onRight = vy > 0
+	} else {
+		// Downward, in y.
+		for _, j := range w.cols.at(m.X) {
+			f := w.edges[j]
+			if (f.a.X > m.X) == (f.b.X > m.X) || coincident(f) {
+				continue
+			}
+			if f.a.Y+(m.X-f.a.X)*(f.b.Y-f.a.Y)/(f.b.X-f.a.X) <= m.Y {
+				continue
+			}
+			if f.b.X < f.a.X {
+				side[f.owner]++
+			} else {
+				side[f.owner]--
+			}
+		}
+		onRight = vx < 0
+	}
+	// Each edge along e raises the winding on its left above that on its
+	// right.
+	fwd, back := w.along[[2]Point{e.a, e.b}], w.along[[2]Point{e.b, e.a}]
+	left, right = side, side
+	for k := range side {
+		if onRight {
+			left[k] += fwd[k] - back[k]
+		} else {
+			right[k] -= fwd[k] - back[k]
+		}
+	}
+	return left, right
+}
+
+// bands divides an interval into bands of equal size, each listing the
+// indices of the items that span it.
+type bands struct {
+	min, size float64
+	items     [][]int
+}
+
+func newBands(min, max float64, count int) bands {
+	size := (max - min) / float64(count)
+	if size <= 0 {
+		size, count = 1, 1
+	}
+	return bands{min, size, make([][]int, count)}
+}
+
+// band returns the index of the band holding v.
+func (b bands) band(v float64) int {
+	i := int(math.Floor((v - b.min) / b.size))
+	if i < 0 {
+		return 0
+	}
+	if i >= len(b.items) {
+		return len(b.items) - 1
+	}
+	return i
+}
+
+// add lists item i in the bands spanning the interval between v0 and v1.
+func (b *bands) add(i int, v0, v1 float64) {
+	for k, end := b.band(math.Min(v0, v1)), b.band(math.Max(v0, v1)); k <= end; k++ {
+		b.items[k] = append(b.items[k], i)
+	}
+}
+
+// at returns the items in the band holding v.
+func (b bands) at(v float64) []int {
+	return b.items[b.band(v)]
+}
+
+// booleanLoops returns the boundary of the area where fill reports true,
+// given each owner's winding numbers, as closed loops with the filled area
+// on their left.
+func booleanLoops(polys []polygon, fill func(w [2]int) bool) [][]Point {
+	const eps = 1e-7
+	edges := splitEdges(polys, eps)
+	type key struct{ a, b Point }
+	done := map[key]bool{}
+	winding := newWindingIndex(edges)
+	var kept []edge
+	for i, e := range edges {
+		k := key{e.a, e.b}
+		if e.b.X < e.a.X || e.b.X == e.a.X && e.b.Y < e.a.Y {
+			k = key{e.b, e.a}
+		}
+		if done[k] {
+			continue
+		}
+		done[k] = true
+		left, right := winding.across(i)
+		inLeft, inRight := fill(left), fill(right)
+		switch {
+		case inLeft && !inRight:
+			kept = append(kept, edge{e.a, e.b, 0})
+		case inRight && !inLeft:
+			kept = append(kept, edge{e.b, e.a, 0})
+		}
+	}
+	return chainEdges(kept)
+}
+
+// chainEdges joins directed edges end to end into closed loops.
+func chainEdges(edges []edge) [][]Point {
+	outgoing := map[Point][]int{}
+	for i, e := range edges {
+		outgoing[e.a] = append(outgoing[e.a], i)
+	}
+	used := make([]bool, len(edges))
+	var loops [][]Point
+	for i := range edges {
+		if used[i] {
+			continue
+		}
+		used[i] = true
+		loop := []Point{edges[i].a}
+		cur := i
+		for {
+			e := edges[cur]
+			if e.b == loop[0] {
+				break
+			}
+			loop = append(loop, e.b)
+			// Where several edges leave the same point, take the one that
+			// turns most sharply left, keeping touching loops apart.
+			next, best := -1, math.Inf(1)
+			for _, j := range outgoing[e.b] {
+				if used[j] {
+					continue
+				}
+				f := edges[j]
+				turn := math.Atan2(cross(e.b.X-e.a.X, e.b.Y-e.a.Y, f.b.X-f.a.X, f.b.Y-f.a.Y),
+					(e.b.X-e.a.X)*(f.b.X-f.a.X)+(e.b.Y-e.a.Y)*(f.b.Y-f.a.Y))
+				if -turn < best {
+					next, best = j, -turn
+				}
+			}
+			if next < 0 {
+				break
+			}
+			used[next] = true
+			cur = next
+		}
+		if loop = simplifyCollinear(loop); len(loop) >= 3 {
+			loops = append(loops, loop)
+		}
+	}
+	return loops
+}
+
+// simplifyCollinear removes the points of a closed loop that lie on the
+// straight line between their neighbours.
+func simplifyCollinear(loop []Point) []Point {
+	for changed := true; changed && len(loop) >= 3; {
+		changed = false
+		var out []Point
+		n := len(loop)
+		for i, p := range loop {
+			prev, next := loop[(i+n-1)%n], loop[(i+1)%n]
+			if len(out) > 0 {
+				prev = out[len(out)-1]
+			}
+			dx1, dy1 := p.X-prev.X, p.Y-prev.Y
+			dx2, dy2 := next.X-p.X, next.Y-p.Y
+			l := math.Hypot(dx2, dy2) + math.Hypot(dx1, dy1)
+			if math.Abs(cross(dx1, dy1, dx2, dy2)) <= 1e-9*l*l && dx1*dx2+dy1*dy2 >= 0 {
+				changed = true
+				continue
+			}
+			out = append(out, p)
+		}
+		loop = out
+	}
+	return loop
+}
+
+// pathFromLoops returns a path with one closed contour per loop.
+func pathFromLoops(loops [][]Point) TextPath {
+	var result TextPath
+	for _, loop := range loops {
+		result.MoveTo(loop[0].X, loop[0].Y)
+		for _, p := range loop[1:] {
+			result.LineTo(p.X, p.Y)
+		}
+		result.LineTo(loop[0].X, loop[0].Y)
+	}
+	return result
+}
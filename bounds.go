@@ -0,0 +1,81 @@
+package filmore
+
+import "math"
+
+// Bounds returns the smallest rectangle that contains p's outline, such as
+// for sizing a canvas to fit the text. Curves are measured at their
+// extremes, not at their control points, so the box is tight. A path with no
+// ops has bounds of all zeros.
+func (p *TextPath) Bounds() (minX, minY, maxX, maxY float64) {
+	if len(p.PathOps) == 0 {
+		return 0, 0, 0, 0
+	}
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	add := func(x, y float64) {
+		minX, minY = math.Min(minX, x), math.Min(minY, y)
+		maxX, maxY = math.Max(maxX, x), math.Max(maxY, y)
+	}
+	var x0, y0 float64
+	for _, op := range p.PathOps {
+		x1, y1 := op.X(), op.Y()
+		add(x1, y1)
+		switch op := op.(type) {
+		case QuadCurveTo:
+			tx, ty := quadExtreme(x0, op.cx, x1), quadExtreme(y0, op.cy, y1)
+			add(quadAt(x0, op.cx, x1, tx), quadAt(y0, op.cy, y1, tx))
+			add(quadAt(x0, op.cx, x1, ty), quadAt(y0, op.cy, y1, ty))
+		case CubicCurveTo:
+			for _, t := range append(cubicExtremes(x0, op.cx1, op.cx2, x1), cubicExtremes(y0, op.cy1, op.cy2, y1)...) {
+				add(cubicAt(x0, op.cx1, op.cx2, x1, t), cubicAt(y0, op.cy1, op.cy2, y1, t))
+			}
+		}
+		x0, y0 = x1, y1
+	}
+	return minX, minY, maxX, maxY
+}
+
+// quadExtreme returns the parameter at which a coordinate of a quadratic
+// Bézier with the given start, control and end values turns, or 0 if it
+// doesn't turn between the ends.
+func quadExtreme(a, c, b float64) float64 {
+	d := a - 2*c + b
+	if d == 0 {
+		return 0
+	}
+	if t := (a - c) / d; t > 0 && t < 1 {
+		return t
+	}
+	return 0
+}
+
+// cubicExtremes returns the parameters at which a coordinate of a cubic
+// Bézier with the given start, control and end values turns between the
+// ends.
+func cubicExtremes(a, c1, c2, b float64) []float64 {
+	// The derivative is a quadratic, qa t² + qb t + qc, up to a factor of 3.
+	d0, d1, d2 := c1-a, c2-c1, b-c2
+	qa, qb, qc := d0-2*d1+d2, 2*(d1-d0), d0
+	var roots []float64
+	if math.Abs(qa) < 1e-12 {
+		if qb != 0 {
+			roots = append(roots, -qc/qb)
+		}
+	} else if disc := qb*qb - 4*qa*qc; disc >= 0 {
+		sq := math.Sqrt(disc)
+		roots = append(roots, (-qb+sq)/(2*qa), (-qb-sq)/(2*qa))
+	}
+	var result []float64
+	for _, t := range roots {
+		if t > 0 && t < 1 {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// quadAt returns a coordinate of a quadratic Bézier at parameter t.
+func quadAt(a, c, b, t float64) float64 {
+	mt := 1 - t
+	return mt*mt*a + 2*mt*t*c + t*t*b
+}
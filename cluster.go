@@ -0,0 +1,61 @@
+package filmore
+
+import "unicode"
+
+const zwj = 0x200D
+
+func isVariationSelector(r rune) bool {
+	return inRange(r, 0xFE00, 0xFE0F) || inRange(r, 0xE0100, 0xE01EF)
+}
+
+func isEmojiModifier(r rune) bool { return inRange(r, 0x1F3FB, 0x1F3FF) }
+
+func isRegionalIndicator(r rune) bool { return inRange(r, 0x1F1E6, 0x1F1FF) }
+
+func isTag(r rune) bool { return inRange(r, 0xE0020, 0xE007F) }
+
+// isDefaultIgnorable reports whether r has no visible form of its own, so
+// that a font without a glyph for it should draw nothing rather than .notdef.
+func isDefaultIgnorable(r rune) bool {
+	return r == 0xAD || r == 0x034F || r == 0x061C || inRange(r, 0x115F, 0x1160) ||
+		inRange(r, 0x17B4, 0x17B5) || inRange(r, 0x180B, 0x180F) || inRange(r, 0x200B, 0x200F) ||
+		inRange(r, 0x202A, 0x202E) || inRange(r, 0x2060, 0x206F) || r == 0x3164 ||
+		isVariationSelector(r) || r == 0xFEFF || r == 0xFFA0 || inRange(r, 0x1BCA0, 0x1BCA3) ||
+		inRange(r, 0x1D173, 0x1D17A) || inRange(r, 0xE0000, 0xE0FFF)
+}
+
+func isEmoji(r rune) bool { return unicode.Is(unicode.So, r) || r >= 0x1F000 && r < 0x1FB00 }
+
+// emojiClusters splits text into runs that should be looked up as a unit:
+// emoji joined by zero width joiners, characters followed by variation
+// selectors, emoji modifiers or tag sequences, keycaps, and pairs of
+// regional indicators forming a flag. Every other rune is a cluster of its
+// own. It returns the start index of each cluster.
+func emojiClusters(text []rune) []int {
+	var starts []int
+	for i := 0; i < len(text); i = emojiClusterEnd(text, i) {
+		starts = append(starts, i)
+	}
+	return starts
+}
+
+// emojiClusterEnd returns the end of the cluster starting at text[i].
+func emojiClusterEnd(text []rune, i int) int {
+	r := text[i]
+	i++
+	if isRegionalIndicator(r) && i < len(text) && isRegionalIndicator(text[i]) {
+		return i + 1
+	}
+	for i < len(text) {
+		switch next := text[i]; {
+		case isVariationSelector(next), isEmojiModifier(next), isTag(next), next == 0x20E3:
+			i++
+		case next == zwj && isEmoji(r) && i+1 < len(text) && isEmoji(text[i+1]):
+			r = text[i+1]
+			i += 2
+		default:
+			return i
+		}
+	}
+	return i
+}
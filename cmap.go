@@ -0,0 +1,69 @@
+package filmore
+
+// HasGlyph reports whether the font can draw r, either with a glyph of its
+// own or with an outline registered by SetGlyphOverride.
+func (f *Font) HasGlyph(r rune) bool {
+	if _, ok := f.overrides[r]; ok {
+		return true
+	}
+	return f.font.Index(r) != 0
+}
+
+// isMissing reports whether f lacks a glyph for a rune that should be
+// visible.
+func (f *Font) isMissing(r rune) bool {
+	return !f.HasGlyph(r) && !isDefaultIgnorable(r) && !isEmojiModifier(r)
+}
+
+// Runes returns the runes that the font has a glyph for, in increasing
+// order, such as for building a specimen sheet.
+func (f *Font) Runes() []rune {
+	var result []rune
+	cmapRanges(f.table("cmap"), func(lo, hi rune) {
+		for r := lo; r <= hi; r++ {
+			if f.font.Index(r) != 0 {
+				result = append(result, r)
+			}
+		}
+	})
+	return result
+}
+
+// cmapRanges calls fn with the ranges of runes, in increasing order, that the
+// Unicode subtable of a cmap table maps. A full repertoire subtable is
+// preferred over one limited to the Basic Multilingual Plane.
+func cmapRanges(cmap []byte, fn func(lo, hi rune)) {
+	var bmp, full []byte
+	for i, n := 0, u16(cmap, 2); i < n; i++ {
+		rec := 4 + 8*i
+		platform, encoding := u16(cmap, rec), u16(cmap, rec+2)
+		st := sub(cmap, u32(cmap, rec+4))
+		switch format := u16(st, 0); {
+		case format == 12 && (platform == 0 || platform == 3 && encoding == 10):
+			full = st
+		case format == 4 && (platform == 0 || platform == 3 && encoding == 1):
+			bmp = st
+		}
+	}
+	if full != nil {
+		for i, n := 0, u32(full, 12); i < n; i++ {
+			group := 16 + 12*i
+			if group+12 > len(full) {
+				break
+			}
+			fn(rune(u32(full, group)), rune(u32(full, group+4)))
+		}
+		return
+	}
+	segments := u16(bmp, 6) / 2
+	for i := 0; i < segments; i++ {
+		end, start := u16(bmp, 14+2*i), u16(bmp, 16+2*segments+2*i)
+		// The last segment maps 0xFFFF to .notdef.
+		if end == 0xffff {
+			end--
+		}
+		if start <= end {
+			fn(rune(start), rune(end))
+		}
+	}
+}
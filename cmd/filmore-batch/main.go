@@ -0,0 +1,123 @@
+// Command filmore-batch renders a template once for each record of a list,
+// such as name badges for a list of attendees, writing one SVG or PDF file
+// per record.
+//
+// Usage:
+//
+//	filmore-batch -template badge.json -data attendees.csv [-out dir] [-name field] [-format svg|pdf]
+//
+// The template is a JSON file as described for filmore.LoadTemplate. The
+// records are read from a CSV file whose first row names the fields, or from
+// a JSON file holding an array of objects with string values. Each output
+// file is named after the record's value of the field given by -name, or by
+// its number in the list if -name is not given, with the extension of the
+// format given by -format, svg by default.
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/jdf/filmore"
+)
+
+func main() {
+	templateFile := flag.String("template", "", "the template `file`, in JSON")
+	dataFile := flag.String("data", "", "the records, as a CSV or JSON `file`")
+	outDir := flag.String("out", ".", "the `directory` to write the files to")
+	nameField := flag.String("name", "", "the `field` to name each file after")
+	format := flag.String("format", "svg", "the `format` to write, svg or pdf")
+	flag.Parse()
+	if *templateFile == "" || *dataFile == "" || (*format != "svg" && *format != "pdf") {
+		flag.Usage()
+		os.Exit(2)
+	}
+	log.SetFlags(0)
+	log.SetPrefix("filmore-batch: ")
+
+	t, err := filmore.LoadTemplate(*templateFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	records, err := readRecords(*dataFile)
+	if err != nil {
+		log.Fatal(err)
+	}
+	for i, record := range records {
+		name := fmt.Sprintf("%04d", i+1)
+		if *nameField != "" {
+			v, ok := record[*nameField]
+			if !ok {
+				log.Fatalf("record %d has no field %q", i+1, *nameField)
+			}
+			// Keep the file in the output directory whatever the value.
+			name = strings.Map(func(r rune) rune {
+				if r == '/' || r == '\\' || r == filepath.Separator {
+					return '_'
+				}
+				return r
+			}, v)
+		}
+		if err := writeFile(t, filepath.Join(*outDir, name+"."+*format), *format, record); err != nil {
+			log.Fatalf("record %d: %v", i+1, err)
+		}
+	}
+}
+
+// writeFile renders t for record into the file filename, in format.
+func writeFile(t *filmore.Template, filename, format string, record map[string]string) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	write := t.WriteSVG
+	if format == "pdf" {
+		write = t.WritePDF
+	}
+	if err := write(f, record); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// readRecords reads the records of filename, in CSV or JSON according to its
+// extension.
+func readRecords(filename string) ([]map[string]string, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if strings.EqualFold(filepath.Ext(filename), ".json") {
+		var records []map[string]string
+		if err := json.NewDecoder(f).Decode(&records); err != nil {
+			return nil, fmt.Errorf("reading %s: %v", filename, err)
+		}
+		return records, nil
+	}
+	rows, err := csv.NewReader(f).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	var records []map[string]string
+	for _, row := range rows[1:] {
+		record := map[string]string{}
+		for i, field := range rows[0] {
+			if i < len(row) {
+				record[field] = row[i]
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
@@ -0,0 +1,18 @@
+package filmore
+
+// scaleAdvances scales the advances and horizontal offsets of glyphs by sx,
+// as for TextOptions.HorizontalScale.
+func scaleAdvances(glyphs []fontGlyph, sx float64) {
+	for i := range glyphs {
+		glyphs[i].XAdvance *= sx
+		glyphs[i].XOffset *= sx
+	}
+}
+
+// scaleOps scales ops in place horizontally by sx about the vertical line
+// through the glyph origin at x, as for TextOptions.HorizontalScale.
+func scaleOps(ops []Op, x, sx float64) {
+	p := TextPath{PathOps: ops}
+	scaled := p.mapPoints(func(px, py float64) (float64, float64) { return x + (px-x)*sx, py })
+	copy(ops, scaled.PathOps)
+}
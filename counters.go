@@ -0,0 +1,51 @@
+package filmore
+
+// Counters returns the counters of p: the contours that form holes, such as
+// the insides of 'o' and 'A'. A contour is taken to be a hole if it lies
+// inside an odd number of p's other contours. Filled on their own, the
+// counters give the pieces to inlay into text cut from a sheet.
+func (p *TextPath) Counters() TextPath {
+	return p.contoursByDepth(true)
+}
+
+// OuterContours returns p without its counters, so that filled it gives the
+// solid silhouettes of the letters, as for stencils and two-material prints.
+// Contours within counters, such as the middle of ⊚, are kept. See Counters.
+func (p *TextPath) OuterContours() TextPath {
+	return p.contoursByDepth(false)
+}
+
+// contoursByDepth returns the contours of p that lie inside an odd number of
+// others if odd is true, and those inside an even number otherwise.
+func (p *TextPath) contoursByDepth(odd bool) TextPath {
+	polys := p.Flatten(defaultTolerance)
+	result := TextPath{Width: p.Width, Height: p.Height}
+	for i, poly := range polys {
+		depth := 0
+		for j, other := range polys {
+			if j != i && insidePolyline(poly[0], other) {
+				depth++
+			}
+		}
+		if (depth%2 == 1) != odd {
+			continue
+		}
+		if start, end, ok := p.subPath(i); ok {
+			result.PathOps = append(result.PathOps, p.PathOps[start:end]...)
+		}
+	}
+	return result
+}
+
+// insidePolyline reports whether pt lies inside the closed polyline poly by
+// the even-odd rule.
+func insidePolyline(pt Point, poly []Point) bool {
+	in := false
+	for i := range poly {
+		a, b := poly[i], poly[(i+1)%len(poly)]
+		if (a.Y > pt.Y) != (b.Y > pt.Y) && pt.X < a.X+(pt.Y-a.Y)*(b.X-a.X)/(b.Y-a.Y) {
+			in = !in
+		}
+	}
+	return in
+}
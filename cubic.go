@@ -0,0 +1,19 @@
+package filmore
+
+// ToCubic replaces each quadratic curve of p with the cubic curve that
+// draws exactly the same shape, in place, for formats such as PDF and
+// PostScript that only have cubic curves. The ops keep their places, so
+// Sources stay valid.
+func (p *TextPath) ToCubic() {
+	var x0, y0 float64
+	for i, op := range p.PathOps {
+		if q, ok := op.(QuadCurveTo); ok {
+			// The cubic's control points are two thirds of the way from each
+			// end to the quadratic's.
+			p.PathOps[i] = CubicCurveTo{q.x, q.y,
+				x0 + 2*(q.cx-x0)/3, y0 + 2*(q.cy-y0)/3,
+				q.x + 2*(q.cx-q.x)/3, q.y + 2*(q.cy-q.y)/3}
+		}
+		x0, y0 = op.X(), op.Y()
+	}
+}
@@ -0,0 +1,130 @@
+package filmore
+
+import "math"
+
+// Dash returns p's outline cut into dashes, for stitched or dotted effects:
+// the lengths in pattern, in pixels, alternate between dashes to draw and
+// gaps to leave, as in SVG's stroke-dasharray, and a pattern with an odd
+// number of lengths is repeated to make it even. Each contour starts phase
+// pixels into the pattern. The dashes are open sub-paths, meant to be
+// stroked, that follow p's curves exactly. A pattern that is empty or adds up
+// to nothing leaves p's outline whole.
+func (p *TextPath) Dash(pattern []float64, phase float64) TextPath {
+	total := 0.0
+	for _, l := range pattern {
+		if l < 0 {
+			return TextPath{Width: p.Width}
+		}
+		total += l
+	}
+	if total == 0 {
+		return TextPath{PathOps: append([]Op(nil), p.PathOps...), Width: p.Width}
+	}
+	if len(pattern)%2 == 1 {
+		pattern = append(append([]float64(nil), pattern...), pattern...)
+		total *= 2
+	}
+	result := TextPath{Width: p.Width}
+	// i is the entry of pattern the pen is in, with left pixels of it to go.
+	var i int
+	var left float64
+	startContour := func() {
+		i, left = 0, math.Mod(phase, total)
+		if left < 0 {
+			left += total
+		}
+		for left >= pattern[i] {
+			left -= pattern[i]
+			i = (i + 1) % len(pattern)
+		}
+		left = pattern[i] - left
+	}
+	startContour()
+	var x0, y0 float64
+	// drawing is whether the last op appended to result ends at x0, y0, so
+	// that a dash continuing into the next segment can carry on from it.
+	drawing := false
+	for _, op := range p.PathOps {
+		if _, ok := op.(MoveTo); ok {
+			startContour()
+			x0, y0, drawing = op.X(), op.Y(), false
+			continue
+		}
+		l := segmentLength(x0, y0, op)
+		at := 0.0
+		for at < l {
+			step := math.Min(left, l-at)
+			if i%2 == 0 && step > 0 {
+				t0, t1 := segmentParameter(x0, y0, op, at, l), segmentParameter(x0, y0, op, at+step, l)
+				if !drawing {
+					x, y := curvePoint(x0, y0, op, t0)
+					result.MoveTo(x, y)
+				}
+				result.PathOps = append(result.PathOps, subSegment(x0, y0, op, t0, t1))
+			}
+			at += step
+			left -= step
+			drawing = i%2 == 0
+			if left <= 0 {
+				i = (i + 1) % len(pattern)
+				left = pattern[i]
+				drawing = false
+			}
+		}
+		x0, y0 = op.X(), op.Y()
+	}
+	return result
+}
+
+// segmentParameter returns the parameter of the point dist along the
+// segment op draws from x0, y0, whose length is l.
+func segmentParameter(x0, y0 float64, op Op, dist, l float64) float64 {
+	if dist <= 0 {
+		return 0
+	}
+	if dist >= l {
+		return 1
+	}
+	switch op.(type) {
+	case QuadCurveTo, CubicCurveTo:
+	default:
+		return dist / l
+	}
+	lo, hi := 0.0, 1.0
+	for k := 0; k < 40; k++ {
+		t := (lo + hi) / 2
+		if curveLength(x0, y0, op, t) < dist {
+			lo = t
+		} else {
+			hi = t
+		}
+	}
+	return (lo + hi) / 2
+}
+
+// subSegment returns the op that draws the part of the segment op draws from
+// x0, y0 between parameters t0 and t1, starting from the point at t0.
+func subSegment(x0, y0 float64, op Op, t0, t1 float64) Op {
+	x, y := curvePoint(x0, y0, op, t1)
+	switch op := op.(type) {
+	case QuadCurveTo:
+		// The control point is the curve's blossom at t0, t1.
+		blossom := func(a, c, b float64) float64 {
+			return (1-t0)*(1-t1)*a + ((1-t0)*t1+t0*(1-t1))*c + t0*t1*b
+		}
+		return QuadCurveTo{x, y, blossom(x0, op.cx, op.x), blossom(y0, op.cy, op.y)}
+	case CubicCurveTo:
+		// The control points are the curve's blossoms at t0, t0, t1 and at
+		// t0, t1, t1.
+		blossom := func(u, v, w, a, c1, c2, b float64) float64 {
+			return (1-u)*(1-v)*(1-w)*a +
+				(u*(1-v)*(1-w)+(1-u)*v*(1-w)+(1-u)*(1-v)*w)*c1 +
+				(u*v*(1-w)+u*(1-v)*w+(1-u)*v*w)*c2 +
+				u*v*w*b
+		}
+		return CubicCurveTo{x, y,
+			blossom(t0, t0, t1, x0, op.cx1, op.cx2, op.x), blossom(t0, t0, t1, y0, op.cy1, op.cy2, op.y),
+			blossom(t0, t1, t1, x0, op.cx1, op.cx2, op.x), blossom(t0, t1, t1, y0, op.cy1, op.cy2, op.y)}
+	}
+	return LineTo{x, y}
+}
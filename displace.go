@@ -0,0 +1,88 @@
+package filmore
+
+import "math"
+
+// maxDisplaceDepth limits how many times Displace halves a stretch of outline
+// to follow fn.
+const maxDisplaceDepth = 8
+
+// Displace returns p with its outline moved along its normals by fn, for
+// organic or distressed lettering: each point x, y of the outline moves by
+// fn(x, y) pixels, outward from the filled area for positive values and
+// inward for negative ones. fn might return noise or a sine wave of x.
+//
+// The outline is sampled at most step pixels apart, and more finely where
+// the displaced outline bends more than a small fraction of a pixel between
+// samples, so step need only be fine enough to catch the detail of fn. The
+// result contains only straight segments.
+func (p *TextPath) Displace(fn func(x, y float64) float64, step float64) TextPath {
+	if step <= 0 {
+		step = 1
+	}
+	result := TextPath{Width: p.Width, Height: p.Height}
+	for _, poly := range p.Flatten(defaultTolerance) {
+		if n := len(poly); n > 1 && poly[0] == poly[n-1] {
+			poly = poly[:n-1]
+		}
+		n := len(poly)
+		if n < 2 {
+			continue
+		}
+		normal := func(a, b Point) Point {
+			dx, dy := b.X-a.X, b.Y-a.Y
+			l := math.Hypot(dx, dy)
+			if l == 0 {
+				return Point{}
+			}
+			// With the filled area on the left, as in glyphs, this points
+			// away from it.
+			return Point{dy / l, -dx / l}
+		}
+		displaced := func(pt, nrm Point) Point {
+			d := fn(pt.X, pt.Y)
+			return Point{pt.X + d*nrm.X, pt.Y + d*nrm.Y}
+		}
+		var out []Point
+		for i := range poly {
+			a, b := poly[i], poly[(i+1)%n]
+			segNormal := normal(a, b)
+			// At the corners, move along the bisector of the normals of the
+			// two sides so that they stay joined.
+			na := normal(poly[(i+n-1)%n], a)
+			na = Point{na.X + segNormal.X, na.Y + segNormal.Y}
+			if l := math.Hypot(na.X, na.Y); l > 1e-9 {
+				na = Point{na.X / l, na.Y / l}
+			} else {
+				na = segNormal
+			}
+			out = append(out, displaced(a, na))
+			pieces := int(math.Ceil(math.Hypot(b.X-a.X, b.Y-a.Y) / step))
+			var refine func(t0, t1 float64, q0, q1 Point, depth int)
+			refine = func(t0, t1 float64, q0, q1 Point, depth int) {
+				tm := (t0 + t1) / 2
+				qm := displaced(Point{a.X + tm*(b.X-a.X), a.Y + tm*(b.Y-a.Y)}, segNormal)
+				if depth < maxDisplaceDepth && distToSegment(qm, q0, q1) > defaultTolerance {
+					refine(t0, tm, q0, qm, depth+1)
+					out = append(out, qm)
+					refine(tm, t1, qm, q1, depth+1)
+				}
+			}
+			prevT, prevQ := 0.0, displaced(a, segNormal)
+			for k := 1; k <= pieces; k++ {
+				t := float64(k) / float64(pieces)
+				q := displaced(Point{a.X + t*(b.X-a.X), a.Y + t*(b.Y-a.Y)}, segNormal)
+				refine(prevT, t, prevQ, q, 0)
+				if k < pieces {
+					out = append(out, q)
+				}
+				prevT, prevQ = t, q
+			}
+		}
+		result.MoveTo(out[0].X, out[0].Y)
+		for _, q := range out[1:] {
+			result.LineTo(q.X, q.Y)
+		}
+		result.LineTo(out[0].X, out[0].Y)
+	}
+	return result
+}
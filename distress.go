@@ -0,0 +1,62 @@
+package filmore
+
+import (
+	"math"
+	"math/rand"
+)
+
+// Distress returns p with the areas of the texture paths cut out of it, for
+// the worn look of distressed type. The textures are typically scans of
+// grunge traced with TraceBitmap, or specks from Speckled; where they
+// overlap, the area is cut out once. See Combine for the form of the result.
+func (p *TextPath) Distress(textures ...*TextPath) TextPath {
+	var texture TextPath
+	for _, t := range textures {
+		texture.PathOps = append(texture.PathOps, t.PathOps...)
+	}
+	return p.Subtract(&texture)
+}
+
+// Speckled returns p with random specks cut out of it. coverage is the
+// fraction of p's bounding box the specks cover before they overlap, such as
+// 0.1, and size their typical diameter in pixels. The same seed always gives
+// the same specks.
+func (p *TextPath) Speckled(coverage, size float64, seed int64) TextPath {
+	minX, minY, maxX, maxY, ok := polylineBounds(p.Flatten(defaultTolerance))
+	if !ok || size <= 0 || coverage <= 0 {
+		return p.Union()
+	}
+	rng := rand.New(rand.NewSource(seed))
+	r := size / 2
+	n := int(coverage * (maxX - minX) * (maxY - minY) / (math.Pi * r * r))
+	var specks TextPath
+	for i := 0; i < n; i++ {
+		cx, cy := minX+rng.Float64()*(maxX-minX), minY+rng.Float64()*(maxY-minY)
+		specks.appendSpeck(cx, cy, r*(0.5+rng.Float64()), rng)
+	}
+	return p.Distress(&specks)
+}
+
+// appendSpeck appends an irregular blob of about radius r around cx, cy,
+// wound like an outer contour so that overlapping specks merge.
+func (p *TextPath) appendSpeck(cx, cy, r float64, rng *rand.Rand) {
+	const corners = 7
+	var pts [corners]Point
+	for i := range pts {
+		a := 2 * math.Pi * float64(i) / corners
+		ri := r * (0.6 + 0.6*rng.Float64())
+		pts[i] = Point{cx + ri*math.Cos(a), cy + ri*math.Sin(a)}
+	}
+	// The corners are the control points of a smooth loop through the
+	// midpoints of its sides.
+	mid := func(i int) Point {
+		a, b := pts[i%corners], pts[(i+1)%corners]
+		return Point{(a.X + b.X) / 2, (a.Y + b.Y) / 2}
+	}
+	start := mid(corners - 1)
+	p.MoveTo(start.X, start.Y)
+	for i := range pts {
+		end := mid(i)
+		p.QuadCurveTo(end.X, end.Y, pts[i].X, pts[i].Y)
+	}
+}
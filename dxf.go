@@ -0,0 +1,61 @@
+package filmore
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// WriteDXF writes p to w as a minimal DXF drawing, for engraving and sign
+// making in CAD programs such as AutoCAD and Fusion. The drawing is in the
+// R12 format, the last whose files need no tables, blocks or entity handles,
+// so each contour becomes a POLYLINE, closed if the contour ends where it
+// starts, with its curves flattened to within tolerance pixels. A tolerance
+// of zero or less selects a twentieth of a pixel. Since y points up in DXF,
+// y is negated, with one drawing unit per pixel.
+func WriteDXF(w io.Writer, p *TextPath, tolerance float64) error {
+	var b strings.Builder
+	group := func(code int, value string) {
+		fmt.Fprintf(&b, "%d\n%s\n", code, value)
+	}
+	point := func(x, y float64) {
+		group(10, svgNumber(x))
+		group(20, svgNumber(-y))
+		group(30, "0")
+	}
+	group(0, "SECTION")
+	group(2, "HEADER")
+	group(9, "$ACADVER")
+	group(1, "AC1009")
+	group(0, "ENDSEC")
+	group(0, "SECTION")
+	group(2, "ENTITIES")
+	for _, pts := range p.Flatten(tolerance) {
+		if len(pts) < 2 {
+			continue
+		}
+		flags := "0"
+		if first, last := pts[0], pts[len(pts)-1]; len(pts) > 2 && first == last {
+			pts = pts[:len(pts)-1]
+			flags = "1"
+		}
+		group(0, "POLYLINE")
+		group(8, "0")
+		// The vertices follow as separate entities; the polyline's own
+		// point only gives its elevation.
+		group(66, "1")
+		point(0, 0)
+		group(70, flags)
+		for _, pt := range pts {
+			group(0, "VERTEX")
+			group(8, "0")
+			point(pt.X, pt.Y)
+		}
+		group(0, "SEQEND")
+		group(8, "0")
+	}
+	group(0, "ENDSEC")
+	group(0, "EOF")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
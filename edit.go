@@ -0,0 +1,134 @@
+package filmore
+
+// splice replaces PathOps[start:end] with ops, keeping Sources pointing at
+// the same glyphs. If owner is the index of a source, that source takes
+// over the new ops; otherwise ops inserted at a glyph boundary are not
+// attributed to either neighbour.
+func (p *TextPath) splice(start, end int, ops []Op, owner int) {
+	delta := len(ops) - (end - start)
+	tail := append([]Op(nil), p.PathOps[end:]...)
+	p.PathOps = append(append(p.PathOps[:start], ops...), tail...)
+	moved := func(i int) int {
+		switch {
+		case i >= end:
+			return i + delta
+		case i > start:
+			return start + len(ops)
+		}
+		return i
+	}
+	for i := range p.Sources {
+		src := &p.Sources[i]
+		if i == owner {
+			src.End = src.Start + len(ops)
+			continue
+		}
+		empty := src.Start == src.End
+		if src.Start >= start {
+			src.Start = moved(src.Start)
+		}
+		if empty {
+			src.End = src.Start
+		} else if src.End > start {
+			src.End = moved(src.End)
+		}
+	}
+}
+
+// subPath returns the bounds of the i'th sub-path of p, which runs from a
+// MoveTo up to the next one. ok is false if there is no such sub-path.
+func (p *TextPath) subPath(i int) (start, end int, ok bool) {
+	if i < 0 {
+		return 0, 0, false
+	}
+	n := -1
+	for j, op := range p.PathOps {
+		if _, isMove := op.(MoveTo); !isMove && j > 0 {
+			continue
+		}
+		if n == i {
+			return start, j, true
+		}
+		n++
+		start = j
+	}
+	if n == i && len(p.PathOps) > 0 {
+		return start, len(p.PathOps), true
+	}
+	return 0, 0, false
+}
+
+// NumSubPaths returns the number of sub-paths in p. Each glyph contour,
+// whether an outer shape or a counter, is a separate sub-path.
+func (p *TextPath) NumSubPaths() int {
+	n := 0
+	for j, op := range p.PathOps {
+		if _, isMove := op.(MoveTo); isMove || j == 0 {
+			n++
+		}
+	}
+	return n
+}
+
+// Contours returns each sub-path of p as a path of its own, in order, so that
+// outer shapes and counters can be processed separately, such as to animate
+// them one by one. If p attributes a contour to a glyph, so does its path.
+func (p *TextPath) Contours() []TextPath {
+	var result []TextPath
+	for i := 0; ; i++ {
+		start, end, ok := p.subPath(i)
+		if !ok {
+			return result
+		}
+		c := TextPath{PathOps: append([]Op(nil), p.PathOps[start:end]...)}
+		for _, src := range p.Sources {
+			if src.Start <= start && end <= src.End {
+				c.Sources = []GlyphSource{{src.RuneIndex, src.Glyph, 0, end - start}}
+				break
+			}
+		}
+		result = append(result, c)
+	}
+}
+
+// DeleteSubPath removes the i'th sub-path from p. It reports whether there
+// was such a sub-path.
+func (p *TextPath) DeleteSubPath(i int) bool {
+	start, end, ok := p.subPath(i)
+	if ok {
+		p.splice(start, end, nil, -1)
+	}
+	return ok
+}
+
+// ReplaceGlyph replaces the ops produced by the rune at runeIndex with those
+// of q, which stay attributed to the same rune. A nil q deletes the glyph's
+// geometry. It reports whether p has attribution for runeIndex; see
+// TextOptions.Attribute.
+func (p *TextPath) ReplaceGlyph(runeIndex int, q *TextPath) bool {
+	for i, src := range p.Sources {
+		if src.RuneIndex == runeIndex {
+			var ops []Op
+			if q != nil {
+				ops = append(ops, q.PathOps...)
+			}
+			p.splice(src.Start, src.End, ops, i)
+			return true
+		}
+	}
+	return false
+}
+
+// InsertPath inserts the ops of q into p just before those of the rune at
+// runeIndex, or at the end of p if runeIndex is past the last attributed
+// rune. The inserted ops aren't attributed to any rune. Nothing is moved to
+// make room; q should already be positioned where it belongs.
+func (p *TextPath) InsertPath(runeIndex int, q *TextPath) {
+	at := len(p.PathOps)
+	for _, src := range p.Sources {
+		if src.RuneIndex >= runeIndex && src.Start < at {
+			at = src.Start
+		}
+	}
+	p.splice(at, at, append([]Op(nil), q.PathOps...), -1)
+}
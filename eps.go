@@ -0,0 +1,54 @@
+package filmore
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// EPS writes p to w as an Encapsulated PostScript file that fills it in
+// black, for prepress and plotting toolchains that take EPS. Since y points
+// up in PostScript, y is negated, so that the path reads the right way up
+// with one point per pixel and text laid out at y = 0 sits on the x axis.
+// The bounding box in the header is the path's tight bounds, as from Bounds,
+// rounded out to whole points.
+// Quadratic curves are written as the cubic curves that draw the same.
+func (p *TextPath) EPS(w io.Writer) error {
+	minX, minY, maxX, maxY := p.Bounds()
+	var b strings.Builder
+	b.WriteString("%!PS-Adobe-3.0 EPSF-3.0\n")
+	fmt.Fprintf(&b, "%%%%BoundingBox: %d %d %d %d\n",
+		int(math.Floor(minX)), int(math.Floor(-maxY)), int(math.Ceil(maxX)), int(math.Ceil(-minY)))
+	fmt.Fprintf(&b, "%%%%HiResBoundingBox: %s %s %s %s\n", svgNumber(minX), svgNumber(-maxY), svgNumber(maxX), svgNumber(-minY))
+	b.WriteString("%%Creator: filmore\n%%EndComments\n")
+	cubic := TextPath{PathOps: append([]Op(nil), p.PathOps...)}
+	cubic.ToCubic()
+	point := func(x, y float64) {
+		b.WriteString(svgNumber(x))
+		b.WriteByte(' ')
+		b.WriteString(svgNumber(-y))
+		b.WriteByte(' ')
+	}
+	b.WriteString("newpath\n")
+	for _, op := range cubic.PathOps {
+		switch op := op.(type) {
+		case MoveTo:
+			point(op.x, op.y)
+			b.WriteString("moveto\n")
+		case LineTo:
+			point(op.x, op.y)
+			b.WriteString("lineto\n")
+		case ClosePath:
+			b.WriteString("closepath\n")
+		case CubicCurveTo:
+			point(op.cx1, op.cy1)
+			point(op.cx2, op.cy2)
+			point(op.x, op.y)
+			b.WriteString("curveto\n")
+		}
+	}
+	b.WriteString("fill\nshowpage\n%%EOF\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,176 @@
+package filmore
+
+import "math"
+
+// defaultTolerance is the flattening tolerance, in pixels, used where the
+// caller has no say in it.
+const defaultTolerance = 0.05
+
+// Point is a location in the same coordinate space as a TextPath's ops.
+type Point struct{ X, Y float64 }
+
+// Flatten approximates each contour of p by a polyline that stays within
+// tolerance pixels of the true outline, for consumers such as plotters and
+// physics engines that only take polygons. Each polyline starts at its
+// contour's MoveTo and follows the ops in order, so a closed contour ends
+// where it began. A tolerance of zero or less selects a twentieth of a pixel.
+func (p *TextPath) Flatten(tolerance float64) [][]Point {
+	if tolerance <= 0 {
+		tolerance = defaultTolerance
+	}
+	var result [][]Point
+	var cur []Point
+	for _, op := range p.PathOps {
+		if _, ok := op.(MoveTo); ok {
+			if len(cur) > 0 {
+				result = append(result, cur)
+			}
+			cur = []Point{{op.X(), op.Y()}}
+			continue
+		}
+		if len(cur) == 0 {
+			cur = []Point{{0, 0}}
+		}
+		switch op := op.(type) {
+		case LineTo, ClosePath:
+			cur = append(cur, Point{op.X(), op.Y()})
+		case QuadCurveTo:
+			cur = appendQuad(cur, Point{op.cx, op.cy}, Point{op.x, op.y}, tolerance)
+		case CubicCurveTo:
+			cur = appendCubic(cur, Point{op.cx1, op.cy1}, Point{op.cx2, op.cy2}, Point{op.x, op.y}, tolerance)
+		}
+	}
+	if len(cur) > 0 {
+		result = append(result, cur)
+	}
+	return result
+}
+
+// Polygonize returns p with its curves replaced by straight segments that
+// stay within tolerance pixels of them, for clipping libraries and geometry
+// engines that can't handle curves. The result has only MoveTo, LineTo and
+// ClosePath ops: contours that end where they start, or that p closes
+// itself, end with a ClosePath. Sources are carried over. A tolerance of zero
+// or less selects a twentieth of a pixel.
+func (p *TextPath) Polygonize(tolerance float64) TextPath {
+	if tolerance <= 0 {
+		tolerance = defaultTolerance
+	}
+	result := TextPath{Width: p.Width, Height: p.Height}
+	// at maps the index of each op to that of the first op it becomes.
+	at := make([]int, len(p.PathOps)+1)
+	var start, pen Point
+	for i, op := range p.PathOps {
+		at[i] = len(result.PathOps)
+		end := Point{op.X(), op.Y()}
+		var pts []Point
+		switch op := op.(type) {
+		case MoveTo:
+			result.MoveTo(end.X, end.Y)
+			start, pen = end, end
+			continue
+		case QuadCurveTo:
+			pts = appendQuad([]Point{pen}, Point{op.cx, op.cy}, end, tolerance)[1:]
+		case CubicCurveTo:
+			pts = appendCubic([]Point{pen}, Point{op.cx1, op.cy1}, Point{op.cx2, op.cy2}, end, tolerance)[1:]
+		default:
+			pts = []Point{end}
+		}
+		for _, pt := range pts[:len(pts)-1] {
+			result.LineTo(pt.X, pt.Y)
+		}
+		_, closes := op.(ClosePath)
+		if !closes && end == start && (i+1 == len(p.PathOps) || isMoveTo(p.PathOps[i+1])) {
+			closes = true
+		}
+		if closes {
+			result.ClosePath()
+		} else {
+			result.LineTo(end.X, end.Y)
+		}
+		pen = end
+	}
+	at[len(p.PathOps)] = len(result.PathOps)
+	for _, src := range p.Sources {
+		src.Start, src.End = at[src.Start], at[src.End]
+		result.Sources = append(result.Sources, src)
+	}
+	return result
+}
+
+// isMoveTo reports whether op starts a new contour.
+func isMoveTo(op Op) bool {
+	_, ok := op.(MoveTo)
+	return ok
+}
+
+// appendQuad appends to poly, whose last point is the start of the curve, line
+// segments approximating the quadratic Bézier through control c to end.
+func appendQuad(poly []Point, c, end Point, tolerance float64) []Point {
+	start := poly[len(poly)-1]
+	// The chord of a quadratic deviates from the curve by at most a quarter of
+	// its second difference, and the deviation falls with the square of the
+	// number of pieces.
+	ddx, ddy := start.X-2*c.X+end.X, start.Y-2*c.Y+end.Y
+	n := int(math.Ceil(math.Sqrt(math.Hypot(ddx, ddy) / (4 * tolerance))))
+	for i := 1; i < n; i++ {
+		t := float64(i) / float64(n)
+		mt := 1 - t
+		poly = append(poly, Point{
+			mt*mt*start.X + 2*mt*t*c.X + t*t*end.X,
+			mt*mt*start.Y + 2*mt*t*c.Y + t*t*end.Y,
+		})
+	}
+	return append(poly, end)
+}
+
+// appendCubic appends to poly, whose last point is the start of the curve,
+// line segments approximating the cubic Bézier through controls c1 and c2 to
+// end.
+func appendCubic(poly []Point, c1, c2, end Point, tolerance float64) []Point {
+	start := poly[len(poly)-1]
+	// The chord of a cubic deviates from the curve by at most three quarters
+	// of the larger of its second differences, and the deviation falls with
+	// the square of the number of pieces.
+	dd := math.Max(
+		math.Hypot(start.X-2*c1.X+c2.X, start.Y-2*c1.Y+c2.Y),
+		math.Hypot(c1.X-2*c2.X+end.X, c1.Y-2*c2.Y+end.Y))
+	n := int(math.Ceil(math.Sqrt(3 * dd / (4 * tolerance))))
+	for i := 1; i < n; i++ {
+		t := float64(i) / float64(n)
+		poly = append(poly, Point{cubicAt(start.X, c1.X, c2.X, end.X, t), cubicAt(start.Y, c1.Y, c2.Y, end.Y, t)})
+	}
+	return append(poly, end)
+}
+
+// cubicAt returns a coordinate of a cubic Bézier at parameter t.
+func cubicAt(a, c1, c2, b, t float64) float64 {
+	mt := 1 - t
+	return mt*mt*mt*a + 3*mt*mt*t*c1 + 3*mt*t*t*c2 + t*t*t*b
+}
+
+// distToSegment returns the distance from p to the segment ab.
+func distToSegment(p, a, b Point) float64 {
+	dx, dy := b.X-a.X, b.Y-a.Y
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		return math.Hypot(p.X-a.X, p.Y-a.Y)
+	}
+	t := ((p.X-a.X)*dx + (p.Y-a.Y)*dy) / l2
+	t = math.Max(0, math.Min(1, t))
+	return math.Hypot(p.X-a.X-t*dx, p.Y-a.Y-t*dy)
+}
+
+// polylineBounds returns the bounding box of a set of polylines. ok is false
+// if there are no points.
+func polylineBounds(polys [][]Point) (minX, minY, maxX, maxY float64, ok bool) {
+	minX, minY = math.Inf(1), math.Inf(1)
+	maxX, maxY = math.Inf(-1), math.Inf(-1)
+	for _, poly := range polys {
+		for _, p := range poly {
+			minX, minY = math.Min(minX, p.X), math.Min(minY, p.Y)
+			maxX, maxY = math.Max(maxX, p.X), math.Max(maxY, p.Y)
+		}
+	}
+	return minX, minY, maxX, maxY, minX <= maxX
+}
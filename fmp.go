@@ -0,0 +1,173 @@
+package filmore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+// FMPVersion is the version of the filmore path document format that
+// WriteDocument writes, following semantic versioning: ReadDocument reads
+// documents of the same major version, whatever their minor version, and
+// later minor versions only add to the format.
+const FMPVersion = "1.2.0"
+
+// A Document is a set of paths with their presentation, as stored in a
+// filmore path document (.fmp file), for passing work between the steps of a
+// toolchain without converting it to a lossier format such as SVG.
+type Document struct {
+	// Metadata holds information about the document as a whole, such as
+	// its title or the program that made it.
+	Metadata map[string]string
+	Blocks   []Block
+}
+
+// A Block is one path of a Document.
+type Block struct {
+	// Name identifies the block to the programs that use the document.
+	Name string
+	Path TextPath
+	// Style holds presentation attributes of the block, named as in SVG,
+	// such as "fill" or "stroke-width".
+	Style map[string]string
+}
+
+// The JSON forms of Document, Block and TextPath in an .fmp file.
+type (
+	fmpDocument struct {
+		Format   string            `json:"format"`
+		Version  string            `json:"version"`
+		Metadata map[string]string `json:"metadata,omitempty"`
+		Blocks   []fmpBlock        `json:"blocks"`
+	}
+	fmpBlock struct {
+		Name    string            `json:"name,omitempty"`
+		Style   map[string]string `json:"style,omitempty"`
+		Width   float64           `json:"width"`
+		Height  float64           `json:"height,omitempty"`
+		Ops     [][]interface{}   `json:"ops"`
+		Sources []fmpSource       `json:"sources,omitempty"`
+	}
+	fmpSource struct {
+		RuneIndex int `json:"rune"`
+		Glyph     int `json:"glyph"`
+		Start     int `json:"start"`
+		End       int `json:"end"`
+	}
+)
+
+// WriteDocument writes doc to w as a filmore path document. The document is
+// JSON, with each op written as an array of its letter in SVG path data and
+// its coordinates, the control point first:
+//
+//	{
+//	  "format": "fmp", "version": "1.2.0",
+//	  "metadata": {"title": "Sign"},
+//	  "blocks": [{
+//	    "name": "heading", "style": {"fill": "#000"}, "width": 120,
+//	    "ops": [["M", 0, 0], ["L", 10, 0], ["Q", 15, 0, 15, 5], ...]
+//	  }]
+//	}
+//
+// Coordinates are written exactly, so reading the document back gives the
+// same paths. Version 1.1 added cubic curves, written as "C" ops, and
+// version 1.2 added ClosePath, written as ["Z"].
+func WriteDocument(w io.Writer, doc *Document) error {
+	out := fmpDocument{Format: "fmp", Version: FMPVersion, Metadata: doc.Metadata, Blocks: []fmpBlock{}}
+	for _, b := range doc.Blocks {
+		fb := fmpBlock{Name: b.Name, Style: b.Style, Width: b.Path.Width, Height: b.Path.Height, Ops: [][]interface{}{}}
+		for _, op := range b.Path.PathOps {
+			switch op := op.(type) {
+			case MoveTo:
+				fb.Ops = append(fb.Ops, []interface{}{"M", op.x, op.y})
+			case LineTo:
+				fb.Ops = append(fb.Ops, []interface{}{"L", op.x, op.y})
+			case ClosePath:
+				fb.Ops = append(fb.Ops, []interface{}{"Z"})
+			case QuadCurveTo:
+				fb.Ops = append(fb.Ops, []interface{}{"Q", op.cx, op.cy, op.x, op.y})
+			case CubicCurveTo:
+				fb.Ops = append(fb.Ops, []interface{}{"C", op.cx1, op.cy1, op.cx2, op.cy2, op.x, op.y})
+			}
+		}
+		for _, s := range b.Path.Sources {
+			fb.Sources = append(fb.Sources, fmpSource{s.RuneIndex, int(s.Glyph), s.Start, s.End})
+		}
+		out.Blocks = append(out.Blocks, fb)
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+// ReadDocument reads a filmore path document written by WriteDocument. It
+// fails if the document is of a different major version of the format.
+func ReadDocument(r io.Reader) (*Document, error) {
+	var in fmpDocument
+	if err := json.NewDecoder(r).Decode(&in); err != nil {
+		return nil, fmt.Errorf("filmore: reading path document: %v", err)
+	}
+	if in.Format != "fmp" {
+		return nil, fmt.Errorf("filmore: not a path document")
+	}
+	major := func(v string) string { return strings.SplitN(v, ".", 2)[0] }
+	if major(in.Version) != major(FMPVersion) {
+		return nil, fmt.Errorf("filmore: path document version %s is not supported", in.Version)
+	}
+	doc := &Document{Metadata: in.Metadata}
+	for i, fb := range in.Blocks {
+		b := Block{Name: fb.Name, Style: fb.Style, Path: TextPath{Width: fb.Width, Height: fb.Height}}
+		for j, op := range fb.Ops {
+			if err := b.Path.appendFMPOp(op); err != nil {
+				return nil, fmt.Errorf("filmore: block %d, op %d: %v", i, j, err)
+			}
+		}
+		for _, s := range fb.Sources {
+			if s.Start < 0 || s.Start > s.End || s.End > len(b.Path.PathOps) {
+				return nil, fmt.Errorf("filmore: block %d: source out of range", i)
+			}
+			b.Path.Sources = append(b.Path.Sources, GlyphSource{s.RuneIndex, truetype.Index(s.Glyph), s.Start, s.End})
+		}
+		doc.Blocks = append(doc.Blocks, b)
+	}
+	return doc, nil
+}
+
+// appendFMPOp appends the op in its .fmp form to p.
+func (p *TextPath) appendFMPOp(op []interface{}) error {
+	if len(op) == 0 {
+		return fmt.Errorf("empty op")
+	}
+	kind, _ := op[0].(string)
+	var v []float64
+	for _, c := range op[1:] {
+		f, ok := c.(float64)
+		if !ok {
+			return fmt.Errorf("coordinate %v is not a number", c)
+		}
+		v = append(v, f)
+	}
+	want := map[string]int{"M": 2, "L": 2, "Z": 0, "Q": 4, "C": 6}
+	n, ok := want[kind]
+	if !ok {
+		return fmt.Errorf("unknown op %s", strconv.Quote(kind))
+	}
+	if len(v) != n {
+		return fmt.Errorf("op %s has %d coordinates, want %d", kind, len(v), n)
+	}
+	switch kind {
+	case "M":
+		p.MoveTo(v[0], v[1])
+	case "L":
+		p.LineTo(v[0], v[1])
+	case "Z":
+		p.ClosePath()
+	case "Q":
+		p.QuadCurveTo(v[2], v[3], v[0], v[1])
+	case "C":
+		p.CubicCurveTo(v[4], v[5], v[0], v[1], v[2], v[3])
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package filmore
+
+import (
+	"log"
+	"unicode"
+)
+
+// A FontSet lays out text with an ordered list of fallback fonts, such as a
+// Latin face followed by CJK and emoji faces. Each rune is drawn with the
+// first font in the list that has a glyph for it.
+type FontSet struct {
+	Fonts []*Font
+}
+
+func NewFontSet(fonts ...*Font) *FontSet {
+	return &FontSet{fonts}
+}
+
+// fontsFor picks the font for each of runes. Combining marks and the
+// invisible parts of emoji sequences stay with the font of the rune before
+// them when it covers them, so that clusters aren't split across fonts.
+func (fs *FontSet) fontsFor(runes []rune) []*Font {
+	fonts := make([]*Font, len(runes))
+	for i, r := range runes {
+		if i > 0 && fonts[i-1] != nil && (unicode.Is(unicode.Mn, r) || r == zwj || isVariationSelector(r) ||
+			isEmojiModifier(r) || isTag(r)) {
+			if prev := fonts[i-1]; prev.HasGlyph(r) || isDefaultIgnorable(r) {
+				fonts[i] = prev
+				continue
+			}
+		}
+		for _, f := range fs.Fonts {
+			if f.HasGlyph(r) {
+				fonts[i] = f
+				break
+			}
+		}
+		if fonts[i] == nil && len(fs.Fonts) > 0 {
+			fonts[i] = fs.Fonts[0]
+		}
+	}
+	return fonts
+}
+
+// CreateTextPath is like Font.CreateTextPath, drawing each rune with the
+// first font in the set that has a glyph for it. Runes that no font covers
+// are drawn with the first font's .notdef glyph.
+func (fs *FontSet) CreateTextPath(s string, x, y float64) TextPath {
+	result, err := fs.CreateTextPathOptions(s, x, y, nil)
+	if err != nil {
+		log.Println(err)
+	}
+	return result
+}
+
+// CreateTextPathOptions is like Font.CreateTextPathOptions, drawing each rune
+// with the first font in the set that has a glyph for it.
+func (fs *FontSet) CreateTextPathOptions(s string, x, y float64, opts *TextOptions) (TextPath, error) {
+	if len(fs.Fonts) == 0 {
+		return TextPath{}, nil
+	}
+	return layoutText([]rune(s), fs.fontsFor, x, y, opts)
+}
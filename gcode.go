@@ -0,0 +1,90 @@
+package filmore
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// GCodeOptions says how WriteGCode cuts a path.
+type GCodeOptions struct {
+	// Scale is the number of millimeters per pixel. Zero means 1.
+	Scale float64
+	// Tolerance is how closely the toolpath follows curves, in pixels, as
+	// for Flatten.
+	Tolerance float64
+	// FeedRate and PlungeRate are the speeds, in millimeters per minute, at
+	// which the tool cuts across the work and moves down into it. Zero
+	// leaves out the F word, so the machine keeps its current speed.
+	FeedRate, PlungeRate float64
+	// SafeZ is the height at which the tool travels between contours, and
+	// CutZ the depth it cuts to, in millimeters; CutZ is usually negative.
+	SafeZ, CutZ float64
+	// DepthPerPass, if positive, cuts each contour in several passes, going
+	// at most this much deeper each time, for tools that can't cut to CutZ
+	// in one go.
+	DepthPerPass float64
+}
+
+// WriteGCode writes p to w as a G-code program that engraves its outline on
+// a CNC machine: each contour is flattened, the tool travels to its start at
+// SafeZ, plunges to the cutting depth, follows it, and lifts again. Since y
+// points up on the machine, y is negated, so that the text reads the right
+// way up with the origin at its top left when laid out at 0, 0. The program
+// uses millimeters and absolute coordinates, and ends with M2.
+func WriteGCode(w io.Writer, p *TextPath, opts GCodeOptions) error {
+	scale := opts.Scale
+	if scale == 0 {
+		scale = 1
+	}
+	depths := []float64{opts.CutZ}
+	if opts.DepthPerPass > 0 {
+		// Passes start from the surface at zero, or from SafeZ if it is below
+		// the surface, and are all the same depth.
+		top := math.Min(0, opts.SafeZ)
+		if n := int(math.Ceil((top - opts.CutZ) / opts.DepthPerPass)); n > 1 {
+			depths = depths[:0]
+			for i := 1; i <= n; i++ {
+				depths = append(depths, top-(top-opts.CutZ)*float64(i)/float64(n))
+			}
+		}
+	}
+	feed := func(rate float64) string {
+		if rate <= 0 {
+			return ""
+		}
+		return " F" + gcodeNumber(rate)
+	}
+	var b strings.Builder
+	b.WriteString("G21 G90\n")
+	fmt.Fprintf(&b, "G0 Z%s\n", gcodeNumber(opts.SafeZ))
+	for _, poly := range p.Flatten(opts.Tolerance) {
+		for _, z := range depths {
+			fmt.Fprintf(&b, "G0 X%s Y%s\n", gcodeNumber(poly[0].X*scale), gcodeNumber(-poly[0].Y*scale))
+			fmt.Fprintf(&b, "G1 Z%s%s\n", gcodeNumber(z), feed(opts.PlungeRate))
+			for i, pt := range poly[1:] {
+				f := ""
+				if i == 0 {
+					f = feed(opts.FeedRate)
+				}
+				fmt.Fprintf(&b, "G1 X%s Y%s%s\n", gcodeNumber(pt.X*scale), gcodeNumber(-pt.Y*scale), f)
+			}
+			fmt.Fprintf(&b, "G0 Z%s\n", gcodeNumber(opts.SafeZ))
+		}
+	}
+	b.WriteString("M2\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// gcodeNumber formats a coordinate or rate for G-code, rounded to a
+// ten-thousandth.
+func gcodeNumber(v float64) string {
+	v = math.Round(v*10000) / 10000
+	if v == 0 {
+		v = 0 // Avoid printing negative zero.
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
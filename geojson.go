@@ -0,0 +1,100 @@
+package filmore
+
+import (
+	"io"
+	"math"
+	"strings"
+)
+
+// WriteGeoJSON writes p to w as a GeoJSON MultiPolygon geometry object, for
+// placing text on web maps and in GIS tools. Each contour is flattened to
+// within tolerance pixels, as for Flatten, and becomes a ring; a contour that
+// lies inside an odd number of others, as for Counters, becomes a hole in
+// the polygon of the contour immediately around it, and every other contour
+// becomes a polygon of its own. Since y points up in GeoJSON, y is negated,
+// and rings are wound as RFC 7946 asks, outer rings counterclockwise and
+// holes clockwise, whichever way p winds its contours. Coordinates are in
+// pixels, rounded to a thousandth, for the caller to place on the map with a
+// transform of its own.
+func WriteGeoJSON(w io.Writer, p *TextPath, tolerance float64) error {
+	var rings [][]Point
+	for _, poly := range p.Flatten(tolerance) {
+		if len(poly) > 1 && poly[len(poly)-1] == poly[0] {
+			poly = poly[:len(poly)-1]
+		}
+		// A ring needs at least three distinct positions.
+		if len(poly) >= 3 {
+			rings = append(rings, poly)
+		}
+	}
+	areas := make([]float64, len(rings))
+	depths := make([]int, len(rings))
+	for i, ring := range rings {
+		areas[i], _ = polygonCenter(ring)
+		for j, other := range rings {
+			if j != i && insidePolyline(ring[0], other) {
+				depths[i]++
+			}
+		}
+	}
+	// Each outer ring starts a polygon, and each hole joins that of the
+	// smallest outer ring one level up that contains it.
+	polygon := make([]int, len(rings))
+	var polygons [][]int
+	for i := range rings {
+		if depths[i]%2 == 0 {
+			polygon[i] = len(polygons)
+			polygons = append(polygons, []int{i})
+		}
+	}
+	for i, ring := range rings {
+		if depths[i]%2 == 0 {
+			continue
+		}
+		parent := -1
+		for j, other := range rings {
+			if depths[j] == depths[i]-1 && insidePolyline(ring[0], other) &&
+				(parent < 0 || math.Abs(areas[j]) < math.Abs(areas[parent])) {
+				parent = j
+			}
+		}
+		if parent >= 0 {
+			polygons[polygon[parent]] = append(polygons[polygon[parent]], i)
+		}
+	}
+	var b strings.Builder
+	b.WriteString(`{"type":"MultiPolygon","coordinates":[`)
+	for k, members := range polygons {
+		if k > 0 {
+			b.WriteByte(',')
+		}
+		b.WriteByte('[')
+		for m, i := range members {
+			if m > 0 {
+				b.WriteByte(',')
+			}
+			// Negating y negates areas, so a ring with a positive area in
+			// TextPath coordinates runs clockwise in GeoJSON.
+			ring := rings[i]
+			if (areas[i] > 0) == (m == 0) {
+				ring = make([]Point, len(rings[i]))
+				for n, pt := range rings[i] {
+					ring[len(ring)-1-n] = pt
+				}
+			}
+			b.WriteByte('[')
+			for n := 0; n <= len(ring); n++ {
+				if n > 0 {
+					b.WriteByte(',')
+				}
+				pt := ring[n%len(ring)]
+				b.WriteString("[" + svgNumber(pt.X) + "," + svgNumber(-pt.Y) + "]")
+			}
+			b.WriteByte(']')
+		}
+		b.WriteByte(']')
+	}
+	b.WriteString("]}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
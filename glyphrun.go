@@ -0,0 +1,104 @@
+package filmore
+
+import (
+	"fmt"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+// A PlacedGlyph is one glyph of a line of text laid out by CreateGlyphRun,
+// with its own outline so that it can be animated or colored on its own.
+type PlacedGlyph struct {
+	// Path is the glyph's outline, in place in the line. Its Width is the
+	// glyph's advance.
+	Path TextPath
+	// X and Y give the glyph's origin, the pen position on the baseline it
+	// was drawn from.
+	X, Y float64
+	// Advance is how far the glyph moves the pen, including kerning.
+	Advance float64
+	// RuneIndex is the position, in runes, of the first rune of s that the
+	// glyph represents.
+	RuneIndex int
+	// Font and Glyph identify the glyph drawn.
+	Font  *Font
+	Glyph truetype.Index
+}
+
+// CreateGlyphRun lays s out as CreateTextPathOptions does, but returns each
+// glyph separately, in display order from left to right. opts may be nil.
+func (f *Font) CreateGlyphRun(s string, x, y float64, opts *TextOptions) ([]PlacedGlyph, error) {
+	return glyphRun([]rune(s), f.allRunes, x, y, opts)
+}
+
+// CreateGlyphRun is like Font.CreateGlyphRun, drawing each rune with the
+// first font in the set that has a glyph for it.
+func (fs *FontSet) CreateGlyphRun(s string, x, y float64, opts *TextOptions) ([]PlacedGlyph, error) {
+	if len(fs.Fonts) == 0 {
+		return nil, nil
+	}
+	return glyphRun([]rune(s), fs.fontsFor, x, y, opts)
+}
+
+func glyphRun(runes []rune, chooseFonts func(runes []rune) []*Font, x, y float64, opts *TextOptions) ([]PlacedGlyph, error) {
+	if opts == nil {
+		opts = &TextOptions{}
+	}
+	glyphs, err := shapeText(runes, chooseFonts, opts)
+	if err != nil {
+		return nil, err
+	}
+	if opts.RightToLeft {
+		for _, g := range glyphs {
+			x -= g.XAdvance
+		}
+	}
+	shifts := baselineShifts(glyphs, opts)
+	result := make([]PlacedGlyph, len(glyphs))
+	for i, g := range glyphs {
+		p := TextPath{Width: g.XAdvance}
+		gx, gy := snapOrigin(x, y+shifts[i], opts.Snap)
+		if err := g.appendStyled(gx, gy, opts, &p); err != nil {
+			return result[:i], err
+		}
+		if opts.Snap == SnapExtrema {
+			snapExtrema(p.PathOps)
+		}
+		p.Quantize(opts.Grid)
+		result[i] = PlacedGlyph{p, gx, gy, g.XAdvance, g.Cluster, g.font, g.Glyph}
+		x += g.XAdvance
+	}
+	return result, nil
+}
+
+// CreateGlyphPath returns the outline of r's glyph alone, with its origin at
+// x, y on the baseline, for layout engines that place glyphs themselves. The
+// path's Width is the glyph's advance, without kerning. Glyph overrides are
+// honored. It fails if f has no glyph for r.
+func (f *Font) CreateGlyphPath(r rune, x, y float64) (TextPath, error) {
+	if o, ok := f.overrides[r]; ok {
+		p := o.path.translated(x, y)
+		p.Width = o.advance
+		return p, nil
+	}
+	if !f.HasGlyph(r) {
+		return TextPath{}, fmt.Errorf("filmore: no glyph for %q", r)
+	}
+	return f.CreateGlyphPathByIndex(f.Index(r), x, y)
+}
+
+// Index returns the index of r's glyph in the font, or 0, the index of the
+// .notdef glyph, if the font has none. Glyph overrides are not taken into
+// account.
+func (f *Font) Index(r rune) truetype.Index {
+	return f.font.Index(r)
+}
+
+// CreateGlyphPathByIndex is like CreateGlyphPath, but takes the glyph by its
+// index in the font, such as from the output of an external shaper like
+// HarfBuzz, which may choose glyphs no rune maps to.
+func (f *Font) CreateGlyphPathByIndex(index truetype.Index, x, y float64) (TextPath, error) {
+	p := TextPath{Width: fUnitsToFloat64(f.font.HMetric(f.scale, index).AdvanceWidth)}
+	err := f.appendGlyphPath(index, x, y, &p)
+	return p, err
+}
@@ -0,0 +1,5 @@
+module github.com/jdf/filmore
+
+go 1.21
+
+require code.google.com/p/freetype-go v0.0.0
@@ -0,0 +1,45 @@
+package filmore
+
+import "strconv"
+
+// CreateNumberedLines lays lines out one below another, each preceded by its
+// line number, for rendering code listings and diffs. The first line's
+// baseline is at y and its number is first. The numbers are right-aligned in
+// a gutter starting at x, as wide as the widest number, and a rule separates
+// the gutter from the text, half an em from each. Lines are spaced by the
+// font's ascent, descent and line gap. The result's Width is that of the
+// gutter and the longest line.
+func (f *Font) CreateNumberedLines(lines []string, x, y float64, first int) TextPath {
+	var result TextPath
+	if len(lines) == 0 {
+		return result
+	}
+	numbers := make([]TextPath, len(lines))
+	gutter := 0.0
+	for i := range lines {
+		numbers[i] = f.CreateTextPath(strconv.Itoa(first+i), 0, 0)
+		if numbers[i].Width > gutter {
+			gutter = numbers[i].Width
+		}
+	}
+	pad := f.EmSize() / 2
+	_, rule := f.Underline()
+	textX := x + gutter + 2*pad + rule
+	lineHeight := f.Ascent() + f.Descent() + f.LineGap()
+	for i, line := range lines {
+		baseline := y + float64(i)*lineHeight
+		numbers[i].Translate(x+gutter-numbers[i].Width, baseline)
+		result.PathOps = append(result.PathOps, numbers[i].PathOps...)
+		text := f.CreateTextPath(line, textX, baseline)
+		result.PathOps = append(result.PathOps, text.PathOps...)
+		if w := textX - x + text.Width; w > result.Width {
+			result.Width = w
+		}
+	}
+	// The rule runs the full height of the lines, so that it joins up with
+	// that of a listing continued below.
+	top := y - f.Ascent()
+	sep := rectPath(x+gutter+pad, top, x+gutter+pad+rule, top+float64(len(lines))*lineHeight)
+	result.PathOps = append(result.PathOps, sep.PathOps...)
+	return result
+}
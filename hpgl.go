@@ -0,0 +1,43 @@
+package filmore
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// WriteHPGL writes p to w as an HP-GL/2 plot that draws its outline with pen
+// 1, for pen and vinyl cutting plotters. Each contour is flattened to within
+// tolerance pixels, as for Flatten, and drawn with the pen down from its
+// start to its end. scale is the number of plotter units per pixel, zero
+// meaning 40, which is one millimeter on most plotters. Coordinates are
+// rounded to whole plotter units, which older plotters require, and y is
+// negated, since it points up on the plotter.
+func WriteHPGL(w io.Writer, p *TextPath, tolerance, scale float64) error {
+	if scale == 0 {
+		scale = 40
+	}
+	point := func(pt Point) string {
+		return fmt.Sprintf("%d,%d", int64(math.Round(pt.X*scale)), int64(math.Round(-pt.Y*scale)))
+	}
+	var b strings.Builder
+	b.WriteString("IN;SP1;PA;\n")
+	for _, poly := range p.Flatten(tolerance) {
+		fmt.Fprintf(&b, "PU%s;", point(poly[0]))
+		if len(poly) > 1 {
+			b.WriteString("PD")
+			for i, pt := range poly[1:] {
+				if i > 0 {
+					b.WriteByte(',')
+				}
+				b.WriteString(point(pt))
+			}
+			b.WriteByte(';')
+		}
+		b.WriteByte('\n')
+	}
+	b.WriteString("PU;SP0;\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
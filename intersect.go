@@ -0,0 +1,150 @@
+package filmore
+
+import "sort"
+
+// IntersectLine returns the points where p's outline meets the segment from
+// x0, y0 to x1, y1, ordered along it from x0, y0, such as for the hatching
+// lines with which plotters fill letters: between the first and second
+// points the segment is inside a well-formed glyph, between the second and
+// third outside, and so on. Curves are intersected exactly rather than
+// flattened. Where the outline crosses the segment at a point shared by two
+// of its segments, the point is given once, and where it only touches the
+// segment, at such a point or along a curve, the point is given twice or not
+// at all, so that the points still pair up. Parts of the outline that run
+// along the segment count as lying to one side of it.
+func (p *TextPath) IntersectLine(x0, y0, x1, y1 float64) []Point {
+	dx, dy := x1-x0, y1-y0
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		return nil
+	}
+	// dist is proportional to the signed distance of a point from the line,
+	// which is a polynomial in the parameter of each segment, with Bernstein
+	// coefficients given by its end and control points.
+	dist := func(x, y float64) float64 { return cross(dx, dy, x-x0, y-y0) }
+	type hit struct {
+		s  float64
+		pt Point
+	}
+	var hits []hit
+	var px, py float64
+	for _, op := range p.PathOps {
+		d0, d3 := dist(px, py), dist(op.X(), op.Y())
+		var coeffs []float64
+		switch op := op.(type) {
+		case MoveTo:
+			px, py = op.x, op.y
+			continue
+		case QuadCurveTo:
+			d1 := dist(op.cx, op.cy)
+			coeffs = []float64{d0, 2 * (d1 - d0), d0 - 2*d1 + d3}
+		case CubicCurveTo:
+			d1, d2 := dist(op.cx1, op.cy1), dist(op.cx2, op.cy2)
+			coeffs = []float64{d0, 3 * (d1 - d0), 3 * (d0 - 2*d1 + d2), -d0 + 3*d1 - 3*d2 + d3}
+		default:
+			coeffs = []float64{d0, d3 - d0}
+		}
+		// Walk along the segment, noting each point where it passes from
+		// one side of the line to the other. Points on the line count as
+		// being on the side where dist is positive.
+		meet := func(t float64) {
+			x, y := curvePoint(px, py, op, t)
+			if s := ((x-x0)*dx + (y-y0)*dy) / l2; s >= -1e-12 && s <= 1+1e-12 {
+				hits = append(hits, hit{s, Point{x, y}})
+			}
+		}
+		ts := []float64{0}
+		for _, t := range unitRoots(coeffs) {
+			if t > 0 && t < 1 {
+				ts = append(ts, t)
+			}
+		}
+		ts = append(ts, 1)
+		side := d0 >= 0
+		for i := 1; i < len(ts); i++ {
+			a, b := ts[i-1], ts[i]
+			if between := polyAt(coeffs, (a+b)/2) >= 0; between != side {
+				meet(a)
+				side = between
+			}
+			at := true
+			if i == len(ts)-1 {
+				at = d3 >= 0
+			}
+			if at != side {
+				meet(b)
+				side = at
+			}
+		}
+		px, py = op.X(), op.Y()
+	}
+	sort.SliceStable(hits, func(i, j int) bool { return hits[i].s < hits[j].s })
+	result := make([]Point, len(hits))
+	for i, h := range hits {
+		result[i] = h.pt
+	}
+	return result
+}
+
+// unitRoots returns the roots between 0 and 1 of the polynomial with the given
+// coefficients, lowest power first, in increasing order. The polynomial is
+// monotonic between the roots of its derivative, so each root is bracketed
+// between them and found by bisection. A polynomial that is zero everywhere
+// has no roots, for the purposes of IntersectLine.
+func unitRoots(coeffs []float64) []float64 {
+	eval := func(t float64) float64 { return polyAt(coeffs, t) }
+	if len(coeffs) < 2 {
+		return nil
+	}
+	deriv := make([]float64, len(coeffs)-1)
+	for i := range deriv {
+		deriv[i] = float64(i+1) * coeffs[i+1]
+	}
+	bounds := append(append([]float64{0}, unitRoots(deriv)...), 1)
+	var roots []float64
+	add := func(t float64) {
+		if len(roots) == 0 || t-roots[len(roots)-1] > 1e-12 {
+			roots = append(roots, t)
+		}
+	}
+	for i := 0; i+1 < len(bounds); i++ {
+		a, b := bounds[i], bounds[i+1]
+		fa, fb := eval(a), eval(b)
+		switch {
+		case fa == 0 && fb == 0:
+			// Zero along the whole interval, which for a monotonic piece
+			// means everywhere.
+			continue
+		case fa == 0:
+			add(a)
+			continue
+		case fb == 0:
+			add(b)
+			continue
+		case (fa < 0) == (fb < 0):
+			continue
+		}
+		for k := 0; k < 60; k++ {
+			m := (a + b) / 2
+			if fm := eval(m); fm == 0 {
+				a, b = m, m
+			} else if (fm < 0) == (fa < 0) {
+				a, fa = m, fm
+			} else {
+				b = m
+			}
+		}
+		add((a + b) / 2)
+	}
+	return roots
+}
+
+// polyAt returns the value at t of the polynomial with the given
+// coefficients, lowest power first.
+func polyAt(coeffs []float64, t float64) float64 {
+	v := 0.0
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		v = v*t + coeffs[i]
+	}
+	return v
+}
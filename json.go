@@ -0,0 +1,201 @@
+package filmore
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+// The JSON forms of an op and of a TextPath. An op is an object naming its
+// kind with its letter in SVG path data, followed by its coordinates:
+//
+//	{"op": "M", "x": 0, "y": 0}
+//	{"op": "L", "x": 10, "y": 0}
+//	{"op": "Q", "x": 15, "y": 5, "cx": 15, "cy": 0}
+//	{"op": "C", "x": 20, "y": 10, "cx1": 20, "cy1": 6, "cx2": 18, "cy2": 10}
+//	{"op": "Z", "x": 0, "y": 0}
+//
+// The x and y of a Z are the start of its contour, and may be left out when
+// reading a TextPath, which works them out from its MoveTo. A TextPath is an
+// object holding its ops and metrics, with its sources in the form of those
+// of a filmore path document:
+//
+//	{"ops": [...], "width": 120, "height": 0, "sources": [{"rune": 0, "glyph": 36, "start": 0, "end": 12}]}
+type (
+	jsonOp struct {
+		Op  string   `json:"op"`
+		X   *float64 `json:"x,omitempty"`
+		Y   *float64 `json:"y,omitempty"`
+		CX  *float64 `json:"cx,omitempty"`
+		CY  *float64 `json:"cy,omitempty"`
+		CX1 *float64 `json:"cx1,omitempty"`
+		CY1 *float64 `json:"cy1,omitempty"`
+		CX2 *float64 `json:"cx2,omitempty"`
+		CY2 *float64 `json:"cy2,omitempty"`
+	}
+	jsonPath struct {
+		Ops     []jsonOp    `json:"ops"`
+		Width   float64     `json:"width"`
+		Height  float64     `json:"height"`
+		Sources []fmpSource `json:"sources,omitempty"`
+	}
+)
+
+// newJSONOp returns the JSON form of op.
+func newJSONOp(op Op) jsonOp {
+	v := func(f float64) *float64 { return &f }
+	switch op := op.(type) {
+	case MoveTo:
+		return jsonOp{Op: "M", X: v(op.x), Y: v(op.y)}
+	case LineTo:
+		return jsonOp{Op: "L", X: v(op.x), Y: v(op.y)}
+	case ClosePath:
+		return jsonOp{Op: "Z", X: v(op.x), Y: v(op.y)}
+	case QuadCurveTo:
+		return jsonOp{Op: "Q", X: v(op.x), Y: v(op.y), CX: v(op.cx), CY: v(op.cy)}
+	case CubicCurveTo:
+		return jsonOp{Op: "C", X: v(op.x), Y: v(op.y), CX1: v(op.cx1), CY1: v(op.cy1), CX2: v(op.cx2), CY2: v(op.cy2)}
+	}
+	return jsonOp{}
+}
+
+// values returns the coordinates of o in the order of the fields of its op
+// type, failing if any are missing. Those of a Z are zero if missing.
+func (o jsonOp) values() ([]float64, error) {
+	var fields []*float64
+	switch o.Op {
+	case "M", "L":
+		fields = []*float64{o.X, o.Y}
+	case "Z":
+		if o.X == nil || o.Y == nil {
+			return []float64{0, 0}, nil
+		}
+		fields = []*float64{o.X, o.Y}
+	case "Q":
+		fields = []*float64{o.X, o.Y, o.CX, o.CY}
+	case "C":
+		fields = []*float64{o.X, o.Y, o.CX1, o.CY1, o.CX2, o.CY2}
+	default:
+		return nil, fmt.Errorf("unknown op %q", o.Op)
+	}
+	v := make([]float64, len(fields))
+	for i, f := range fields {
+		if f == nil {
+			return nil, fmt.Errorf("op %s is missing a coordinate", o.Op)
+		}
+		v[i] = *f
+	}
+	return v, nil
+}
+
+// unmarshalOp decodes the JSON form of an op of kind, returning its
+// coordinates.
+func unmarshalOp(data []byte, kind string) ([]float64, error) {
+	var o jsonOp
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, err
+	}
+	if o.Op != kind {
+		return nil, fmt.Errorf("filmore: op %q is not %q", o.Op, kind)
+	}
+	v, err := o.values()
+	if err != nil {
+		return nil, fmt.Errorf("filmore: %v", err)
+	}
+	return v, nil
+}
+
+func (o MoveTo) MarshalJSON() ([]byte, error)       { return json.Marshal(newJSONOp(o)) }
+func (o LineTo) MarshalJSON() ([]byte, error)       { return json.Marshal(newJSONOp(o)) }
+func (o ClosePath) MarshalJSON() ([]byte, error)    { return json.Marshal(newJSONOp(o)) }
+func (o QuadCurveTo) MarshalJSON() ([]byte, error)  { return json.Marshal(newJSONOp(o)) }
+func (o CubicCurveTo) MarshalJSON() ([]byte, error) { return json.Marshal(newJSONOp(o)) }
+
+func (o *MoveTo) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalOp(data, "M")
+	if err == nil {
+		*o = MoveTo{v[0], v[1]}
+	}
+	return err
+}
+
+func (o *LineTo) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalOp(data, "L")
+	if err == nil {
+		*o = LineTo{v[0], v[1]}
+	}
+	return err
+}
+
+func (o *ClosePath) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalOp(data, "Z")
+	if err == nil {
+		*o = ClosePath{v[0], v[1]}
+	}
+	return err
+}
+
+func (o *QuadCurveTo) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalOp(data, "Q")
+	if err == nil {
+		*o = QuadCurveTo{v[0], v[1], v[2], v[3]}
+	}
+	return err
+}
+
+func (o *CubicCurveTo) UnmarshalJSON(data []byte) error {
+	v, err := unmarshalOp(data, "C")
+	if err == nil {
+		*o = CubicCurveTo{v[0], v[1], v[2], v[3], v[4], v[5]}
+	}
+	return err
+}
+
+// MarshalJSON encodes p as a JSON object holding its ops, metrics and
+// sources, for sending paths to JavaScript clients.
+func (p TextPath) MarshalJSON() ([]byte, error) {
+	out := jsonPath{Ops: []jsonOp{}, Width: p.Width, Height: p.Height}
+	for _, op := range p.PathOps {
+		out.Ops = append(out.Ops, newJSONOp(op))
+	}
+	for _, s := range p.Sources {
+		out.Sources = append(out.Sources, fmpSource{s.RuneIndex, int(s.Glyph), s.Start, s.End})
+	}
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes a TextPath encoded by MarshalJSON into p.
+func (p *TextPath) UnmarshalJSON(data []byte) error {
+	var in jsonPath
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+	path := TextPath{Width: in.Width, Height: in.Height}
+	for i, o := range in.Ops {
+		v, err := o.values()
+		if err != nil {
+			return fmt.Errorf("filmore: op %d: %v", i, err)
+		}
+		switch o.Op {
+		case "M":
+			path.MoveTo(v[0], v[1])
+		case "L":
+			path.LineTo(v[0], v[1])
+		case "Z":
+			path.ClosePath()
+		case "Q":
+			path.QuadCurveTo(v[0], v[1], v[2], v[3])
+		case "C":
+			path.CubicCurveTo(v[0], v[1], v[2], v[3], v[4], v[5])
+		}
+	}
+	for _, s := range in.Sources {
+		if s.Start < 0 || s.Start > s.End || s.End > len(path.PathOps) {
+			return fmt.Errorf("filmore: source out of range")
+		}
+		path.Sources = append(path.Sources, GlyphSource{s.RuneIndex, truetype.Index(s.Glyph), s.Start, s.End})
+	}
+	*p = path
+	return nil
+}
@@ -0,0 +1,263 @@
+package filmore
+
+import (
+	"fmt"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+// fontGlyph is a shaped glyph along with the font it belongs to.
+type fontGlyph struct {
+	ShapedGlyph
+	font *Font
+	// override, if not nil, is drawn in place of the glyph.
+	override *TextPath
+}
+
+// layoutText lays runes out as directed by opts, which may be nil. chooseFonts
+// is called with the runes as they will be shaped, after bidi mirroring, and
+// returns the font to take each rune's glyph from.
+func layoutText(runes []rune, chooseFonts func(runes []rune) []*Font, x, y float64, opts *TextOptions) (TextPath, error) {
+	if opts == nil {
+		opts = &TextOptions{}
+	}
+	glyphs, err := shapeText(runes, chooseFonts, opts)
+	if err != nil {
+		return TextPath{}, err
+	}
+
+	result := TextPath{}
+	for _, g := range glyphs {
+		result.Width += g.XAdvance
+	}
+	if opts.Slant != 0 {
+		result.Width += slantExtra(glyphs, opts.Slant)
+	}
+	if opts.RightToLeft {
+		x -= result.Width
+	}
+	shifts := baselineShifts(glyphs, opts)
+	for i, g := range glyphs {
+		start := len(result.PathOps)
+		gx, gy := snapOrigin(x, y+shifts[i], opts.Snap)
+		if err := g.appendStyled(gx, gy, opts, &result); err != nil {
+			return result, err
+		}
+		if opts.Snap == SnapExtrema {
+			snapExtrema(result.PathOps[start:])
+		}
+		if opts.Attribute {
+			result.Sources = append(result.Sources, GlyphSource{g.Cluster, g.Glyph, start, len(result.PathOps)})
+		}
+		x += g.XAdvance
+	}
+	result.Quantize(opts.Grid)
+	return result, nil
+}
+
+// appendPath appends the outline of g, drawn with the pen at x, y, to p.
+func (g *fontGlyph) appendPath(x, y float64, p *TextPath) error {
+	if g.override != nil {
+		p.PathOps = append(p.PathOps, g.override.translated(x+g.XOffset, y+g.YOffset).PathOps...)
+		return nil
+	}
+	return g.font.appendGlyphPath(g.Glyph, x+g.XOffset, y+g.YOffset, p)
+}
+
+// appendStyled is like appendPath, but applies the synthetic styles of opts
+// to the outline: HorizontalScale, then Embolden, then Slant.
+func (g *fontGlyph) appendStyled(x, y float64, opts *TextOptions, p *TextPath) error {
+	if opts.HorizontalScale <= 0 && opts.Embolden == 0 && opts.Slant == 0 {
+		return g.appendPath(x, y, p)
+	}
+	var glyph TextPath
+	var err error
+	if opts.Embolden != 0 && g.override == nil {
+		glyph, err = g.emboldened(opts)
+		glyph = glyph.translated(x+g.XOffset, y+g.YOffset)
+	} else {
+		err = g.appendPath(x, y, &glyph)
+		if opts.HorizontalScale > 0 {
+			scaleOps(glyph.PathOps, x+g.XOffset, opts.HorizontalScale)
+		}
+		if opts.Embolden != 0 {
+			// Move the glyph into the middle of its widened advance.
+			glyph.Translate(opts.Embolden, 0)
+			glyph = glyph.Offset(opts.Embolden)
+		}
+	}
+	if opts.Slant != 0 {
+		slantOps(glyph.PathOps, y, opts.Slant)
+	}
+	p.PathOps = append(p.PathOps, glyph.PathOps...)
+	return err
+}
+
+// emboldenKey identifies a glyph outline scaled and made bold by
+// TextOptions.
+type emboldenKey struct {
+	glyph           truetype.Index
+	scale, strength float64
+}
+
+// emboldened returns the outline of g, with its origin at 0, 0, scaled by
+// opts.HorizontalScale and made bold by opts.Embolden. Making an outline bold
+// is slow, so the results are kept in g's font for the glyph's other
+// occurrences.
+func (g *fontGlyph) emboldened(opts *TextOptions) (TextPath, error) {
+	f := g.font
+	k := emboldenKey{g.Glyph, opts.HorizontalScale, opts.Embolden}
+	if glyph, ok := f.emboldened[k]; ok {
+		return glyph, nil
+	}
+	var glyph TextPath
+	if err := f.appendGlyphPath(g.Glyph, 0, 0, &glyph); err != nil {
+		return glyph, err
+	}
+	if opts.HorizontalScale > 0 {
+		scaleOps(glyph.PathOps, 0, opts.HorizontalScale)
+	}
+	// Move the glyph into the middle of its widened advance.
+	glyph.Translate(opts.Embolden, 0)
+	glyph = glyph.Offset(opts.Embolden)
+	if f.emboldened == nil {
+		f.emboldened = map[emboldenKey]TextPath{}
+	}
+	f.emboldened[k] = glyph
+	return glyph, nil
+}
+
+// baselineShifts returns how far opts.BaselineOffset moves each of glyphs
+// down, laid out from left to right.
+func baselineShifts(glyphs []fontGlyph, opts *TextOptions) []float64 {
+	shifts := make([]float64, len(glyphs))
+	if opts.BaselineOffset == nil {
+		return shifts
+	}
+	pen := 0.0
+	for i, g := range glyphs {
+		if g.XAdvance == 0 && i > 0 {
+			shifts[i] = shifts[i-1]
+		} else {
+			shifts[i] = opts.BaselineOffset(pen)
+		}
+		pen += g.XAdvance
+	}
+	return shifts
+}
+
+// shapeText returns the glyphs for runes as laid out by layoutText, in
+// left-to-right display order with their clusters indexing into runes.
+func shapeText(runes []rune, chooseFonts func(runes []rune) []*Font, opts *TextOptions) ([]fontGlyph, error) {
+	shaper := opts.Shaper
+	if shaper == nil {
+		shaper = basicShaper{}
+	}
+	levels := make([]int, len(runes))
+	if opts.Bidi {
+		base := 0
+		if opts.RightToLeft {
+			base = 1
+		}
+		levels = bidiLevels(runes, base)
+		runes = append([]rune(nil), runes...)
+		for i, level := range levels {
+			if mirror, ok := bidiMirrors[runes[i]]; ok && level%2 == 1 {
+				runes[i] = mirror
+			}
+		}
+	} else if opts.RightToLeft {
+		for i := range levels {
+			levels[i] = 1
+		}
+	}
+	fonts := chooseFonts(runes)
+	switch opts.Missing {
+	case MissingReplace:
+		if opts.ReplacementPath != nil {
+			break
+		}
+		replacement := opts.Replacement
+		if replacement == 0 {
+			replacement = '\uFFFD'
+		}
+		replaced := append([]rune(nil), runes...)
+		for i, r := range runes {
+			if fonts[i].isMissing(r) {
+				replaced[i] = replacement
+			}
+		}
+		runes, fonts = replaced, chooseFonts(replaced)
+	case MissingError:
+		for i, r := range runes {
+			if fonts[i].isMissing(r) {
+				return nil, fmt.Errorf("filmore: no glyph for %q", r)
+			}
+		}
+	}
+	glyphs := shapeRuns(shaper, runes, fonts, levels)
+	if opts.Missing == MissingSkip || opts.Missing == MissingReplace && opts.ReplacementPath != nil {
+		kept := glyphs[:0]
+		for _, g := range glyphs {
+			if g.override == nil && g.Glyph == 0 && g.font.isMissing(runes[g.Cluster]) {
+				if opts.Missing == MissingSkip {
+					continue
+				}
+				g.override = opts.ReplacementPath
+				g.XAdvance, g.XOffset, g.YOffset = opts.ReplacementPath.Width, 0, 0
+			}
+			kept = append(kept, g)
+		}
+		glyphs = kept
+	}
+	if opts.SmallCaps {
+		smallCaps(glyphs, runes)
+	}
+	glyphs = trimWhitespace(glyphs, runes, opts.Whitespace)
+	if opts.ShowInvisibles {
+		showInvisibles(glyphs, runes)
+	}
+	if opts.HorizontalScale > 0 {
+		scaleAdvances(glyphs, opts.HorizontalScale)
+	}
+	emboldenAdvances(glyphs, opts.Embolden)
+	return glyphs, nil
+}
+
+// shapeRuns splits runes into runs with the same font and bidi level, shapes
+// each with shaper, and returns the glyphs in left-to-right display order
+// with their clusters indexing into runes.
+func shapeRuns(shaper Shaper, runes []rune, fonts []*Font, levels []int) []fontGlyph {
+	var runLevels []int
+	var runs [][]fontGlyph
+	for start := 0; start < len(runes); {
+		end := start + 1
+		for end < len(runes) && levels[end] == levels[start] && fonts[end] == fonts[start] {
+			end++
+		}
+		rtl := levels[start]%2 == 1
+		shaped := shaper.Shape(fonts[start], runes[start:end], rtl)
+		glyphs := make([]fontGlyph, len(shaped))
+		for i, g := range shaped {
+			g.Cluster += start
+			glyphs[i] = fontGlyph{g, fonts[start], nil}
+			if o, ok := fonts[start].overrides[runes[g.Cluster]]; ok {
+				glyphs[i].override = &o.path
+				glyphs[i].XAdvance = o.advance
+			}
+		}
+		if rtl {
+			for a, b := 0, len(glyphs)-1; a < b; a, b = a+1, b-1 {
+				glyphs[a], glyphs[b] = glyphs[b], glyphs[a]
+			}
+		}
+		runs = append(runs, glyphs)
+		runLevels = append(runLevels, levels[start])
+		start = end
+	}
+	var result []fontGlyph
+	for _, i := range visualOrder(runLevels) {
+		result = append(result, runs[i]...)
+	}
+	return result
+}
@@ -0,0 +1,175 @@
+package filmore
+
+import "math"
+
+// lengthTolerance is the error, in pixels, to which the lengths of curves are
+// measured.
+const lengthTolerance = 1e-6
+
+// Length returns the total length of p's outline, in pixels, such as for
+// timing an animation that draws it or estimating how long a plotter or
+// engraver takes to trace it. Moves between contours don't count.
+func (p *TextPath) Length() float64 {
+	total := 0.0
+	for _, l := range p.ContourLengths() {
+		total += l
+	}
+	return total
+}
+
+// ContourLengths returns the length of each contour of p, in pixels, in the
+// order the contours appear.
+func (p *TextPath) ContourLengths() []float64 {
+	var result []float64
+	var x0, y0 float64
+	for _, op := range p.PathOps {
+		if _, ok := op.(MoveTo); ok || len(result) == 0 {
+			result = append(result, 0)
+		}
+		result[len(result)-1] += segmentLength(x0, y0, op)
+		x0, y0 = op.X(), op.Y()
+	}
+	return result
+}
+
+// segmentLength returns the length of the segment op draws from x0, y0, which
+// is zero for a MoveTo.
+func segmentLength(x0, y0 float64, op Op) float64 {
+	switch op := op.(type) {
+	case LineTo, ClosePath:
+		return math.Hypot(op.X()-x0, op.Y()-y0)
+	case QuadCurveTo, CubicCurveTo:
+		return curveLength(x0, y0, op, 1)
+	}
+	return 0
+}
+
+// curveLength returns the length of the curve op draws from x0, y0, up to
+// parameter t.
+func curveLength(x0, y0 float64, op Op, t float64) float64 {
+	speed := func(t float64) float64 {
+		return math.Hypot(curveDerivative(x0, y0, op, t))
+	}
+	return integrate(speed, 0, t, lengthTolerance)
+}
+
+// curveDerivative returns the derivative with respect to its parameter of
+// the curve op draws from x0, y0, at parameter t.
+func curveDerivative(x0, y0 float64, op Op, t float64) (dx, dy float64) {
+	mt := 1 - t
+	switch op := op.(type) {
+	case QuadCurveTo:
+		return 2*mt*(op.cx-x0) + 2*t*(op.x-op.cx), 2*mt*(op.cy-y0) + 2*t*(op.y-op.cy)
+	case CubicCurveTo:
+		return 3*mt*mt*(op.cx1-x0) + 6*mt*t*(op.cx2-op.cx1) + 3*t*t*(op.x-op.cx2),
+			3*mt*mt*(op.cy1-y0) + 6*mt*t*(op.cy2-op.cy1) + 3*t*t*(op.y-op.cy2)
+	}
+	return op.X() - x0, op.Y() - y0
+}
+
+// integrate returns the integral of fn from a to b, to within about eps, by
+// adaptive Simpson's rule.
+func integrate(fn func(float64) float64, a, b, eps float64) float64 {
+	fa, fm, fb := fn(a), fn((a+b)/2), fn(b)
+	return simpson(fn, a, b, fa, fm, fb, (b-a)/6*(fa+4*fm+fb), eps, 20)
+}
+
+// simpson refines whole, the Simpson's rule estimate of the integral of fn
+// over a, b, until halving the interval changes it by less than eps or depth
+// runs out.
+func simpson(fn func(float64) float64, a, b, fa, fm, fb, whole, eps float64, depth int) float64 {
+	m := (a + b) / 2
+	lm, rm := fn((a+m)/2), fn((m+b)/2)
+	left := (m - a) / 6 * (fa + 4*lm + fm)
+	right := (b - m) / 6 * (fm + 4*rm + fb)
+	if d := left + right - whole; depth <= 0 || math.Abs(d) <= 15*eps {
+		return left + right + d/15
+	}
+	return simpson(fn, a, m, fa, lm, fm, left, eps/2, depth-1) +
+		simpson(fn, m, b, fm, rm, fb, right, eps/2, depth-1)
+}
+
+// PointAt returns the point dist pixels along p's outline, measured as for
+// Length, and the direction of the outline there, in radians from the
+// rightward horizontal; y points down, so positive angles turn clockwise on
+// the page. It serves for revealing text stroke by stroke and for placing
+// decorations along glyph outlines. Distances beyond the ends of the outline
+// give its ends.
+func (p *TextPath) PointAt(dist float64) (x, y, tangentAngle float64) {
+	var x0, y0 float64
+	var last Op
+	var lastX, lastY float64
+	for _, op := range p.PathOps {
+		l := segmentLength(x0, y0, op)
+		if l > 0 {
+			if dist <= l {
+				return pointOnSegment(x0, y0, op, dist, l)
+			}
+			dist -= l
+			last, lastX, lastY = op, x0, y0
+		}
+		x0, y0 = op.X(), op.Y()
+	}
+	if last == nil {
+		return x0, y0, 0
+	}
+	return pointOnSegment(lastX, lastY, last, math.Inf(1), 0)
+}
+
+// pointOnSegment returns the point dist along the segment op draws from x0,
+// y0, whose length is l, and its direction there. Distances outside the
+// segment give its ends.
+func pointOnSegment(x0, y0 float64, op Op, dist, l float64) (x, y, angle float64) {
+	x1, y1 := op.X(), op.Y()
+	switch op.(type) {
+	case QuadCurveTo, CubicCurveTo:
+	default:
+		t := math.Max(0, math.Min(1, dist/l))
+		return x0 + t*(x1-x0), y0 + t*(y1-y0), math.Atan2(y1-y0, x1-x0)
+	}
+	t := 1.0
+	switch {
+	case dist <= 0:
+		t = 0
+	case dist < l:
+		// Bisect for the parameter at which the curve is dist long.
+		lo, hi := 0.0, 1.0
+		for i := 0; i < 40; i++ {
+			t = (lo + hi) / 2
+			if curveLength(x0, y0, op, t) < dist {
+				lo = t
+			} else {
+				hi = t
+			}
+		}
+	}
+	dx, dy := curveDerivative(x0, y0, op, t)
+	if math.Hypot(dx, dy) < 1e-9 {
+		// A control point is on an end; look a little way along the curve
+		// for the direction it heads in.
+		t2 := math.Min(t+1e-3, 1)
+		if t == 1 {
+			t2 = t - 1e-3
+		}
+		px, py := curvePoint(x0, y0, op, t2)
+		qx, qy := curvePoint(x0, y0, op, t)
+		dx, dy = px-qx, py-qy
+		if t == 1 {
+			dx, dy = -dx, -dy
+		}
+	}
+	x, y = curvePoint(x0, y0, op, t)
+	return x, y, math.Atan2(dy, dx)
+}
+
+// curvePoint returns the point at parameter t of the curve op draws from x0,
+// y0.
+func curvePoint(x0, y0 float64, op Op, t float64) (x, y float64) {
+	switch op := op.(type) {
+	case QuadCurveTo:
+		return quadAt(x0, op.cx, op.x, t), quadAt(y0, op.cy, op.y, t)
+	case CubicCurveTo:
+		return cubicAt(x0, op.cx1, op.cx2, op.x, t), cubicAt(y0, op.cy1, op.cy2, op.y, t)
+	}
+	return x0 + t*(op.X()-x0), y0 + t*(op.Y()-y0)
+}
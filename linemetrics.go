@@ -0,0 +1,75 @@
+package filmore
+
+// Ascent returns how far the font's glyphs reach above the baseline, in
+// pixels, as given by the font's hhea table.
+func (f *Font) Ascent() float64 {
+	return f.fUnitsToPixels(i16(f.table("hhea"), 4))
+}
+
+// Descent returns how far the font's glyphs reach below the baseline, in
+// pixels, as a positive distance.
+func (f *Font) Descent() float64 {
+	return -f.fUnitsToPixels(i16(f.table("hhea"), 6))
+}
+
+// LineGap returns the extra space the font asks for between the descent of
+// one line and the ascent of the next, in pixels. The distance from one
+// baseline to the next is Ascent() + Descent() + LineGap().
+func (f *Font) LineGap() float64 {
+	return f.fUnitsToPixels(i16(f.table("hhea"), 8))
+}
+
+// CapHeight returns the height of the font's flat capital letters above the
+// baseline, in pixels, such as for centering a label optically on its
+// capitals. It comes from the OS/2 table, or is measured from 'H' if the
+// table doesn't give it.
+func (f *Font) CapHeight() float64 {
+	return f.letterHeight(88, 'H')
+}
+
+// XHeight returns the height of the font's flat lowercase letters above the
+// baseline, in pixels. It comes from the OS/2 table, or is measured from 'x'
+// if the table doesn't give it.
+func (f *Font) XHeight() float64 {
+	return f.letterHeight(86, 'x')
+}
+
+// letterHeight returns the OS/2 height at offset, which version 2 of the
+// table added, or else the height of the top of r's glyph.
+func (f *Font) letterHeight(offset int, r rune) float64 {
+	os2 := f.table("OS/2")
+	if h := i16(os2, offset); u16(os2, 0) >= 2 && h > 0 {
+		return f.fUnitsToPixels(h)
+	}
+	_, _, _, ymax, err := f.glyphBounds(f.font.Index(r))
+	if err != nil {
+		return 0
+	}
+	return ymax
+}
+
+// Underline returns where the font's designer would put an underline, in
+// pixels. offset is measured down from the baseline to the top of the line,
+// so the underline of text set on the baseline y runs from y+offset to
+// y+offset+thickness. It comes from the post table, with a guess from the em
+// size for fonts without one.
+func (f *Font) Underline() (offset, thickness float64) {
+	post := f.table("post")
+	if t := i16(post, 10); t > 0 {
+		return -f.fUnitsToPixels(i16(post, 8)), f.fUnitsToPixels(t)
+	}
+	return f.EmSize() / 10, f.EmSize() / 14
+}
+
+// Strikeout returns where the font's designer would put a line striking
+// through text, in pixels, measured as for Underline. The offset is usually
+// negative, the line being above the baseline. It comes from the OS/2 table,
+// or is centered on half the x-height for fonts without one.
+func (f *Font) Strikeout() (offset, thickness float64) {
+	os2 := f.table("OS/2")
+	if t := i16(os2, 26); t > 0 {
+		return -f.fUnitsToPixels(i16(os2, 28)), f.fUnitsToPixels(t)
+	}
+	_, thickness = f.Underline()
+	return -(f.XHeight() + thickness) / 2, thickness
+}
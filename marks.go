@@ -0,0 +1,173 @@
+package filmore
+
+import (
+	"unicode"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+type anchor struct {
+	x, y int
+	ok   bool
+}
+
+func anchorAt(table []byte, offset int) anchor {
+	a := sub(table, offset)
+	return anchor{i16(a, 2), i16(a, 4), offset != 0}
+}
+
+type markRecord struct {
+	class  int
+	anchor anchor
+}
+
+// markToBase is a GPOS mark-to-base attachment subtable.
+type markToBase struct {
+	marks map[truetype.Index]markRecord
+	// bases holds each base glyph's anchors, indexed by mark class.
+	bases map[truetype.Index][]anchor
+}
+
+// parseMarkToBase returns the mark-to-base subtables of the GPOS mark
+// feature.
+func parseMarkToBase(gpos []byte) []markToBase {
+	var result []markToBase
+	for _, l := range featureLookups(gpos, "mark") {
+		lookupType, subtables := lookupSubtables(gpos, l, 9)
+		if lookupType != 4 {
+			continue
+		}
+		for _, st := range subtables {
+			if u16(st, 0) != 1 {
+				continue
+			}
+			classes := u16(st, 6)
+			markArray, baseArray := sub(st, u16(st, 8)), sub(st, u16(st, 10))
+			t := markToBase{map[truetype.Index]markRecord{}, map[truetype.Index][]anchor{}}
+			coverage(sub(st, u16(st, 2)), func(g truetype.Index, i int) {
+				rec := 2 + 4*i
+				t.marks[g] = markRecord{u16(markArray, rec), anchorAt(markArray, u16(markArray, rec+2))}
+			})
+			coverage(sub(st, u16(st, 4)), func(g truetype.Index, i int) {
+				anchors := make([]anchor, classes)
+				for c := range anchors {
+					anchors[c] = anchorAt(baseArray, u16(baseArray, 2+2*(i*classes+c)))
+				}
+				t.bases[g] = anchors
+			})
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+// isMark reports whether the glyph for r should attach to the preceding
+// base rather than advance the pen.
+func (f *Font) isMark(r rune, glyph truetype.Index) bool {
+	if unicode.Is(unicode.Mn, r) || unicode.Is(unicode.Me, r) {
+		return true
+	}
+	for _, t := range f.markBases() {
+		if _, ok := t.marks[glyph]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Font) markBases() []markToBase {
+	if !f.marksLoaded {
+		f.marks = parseMarkToBase(f.table("GPOS"))
+		f.marksLoaded = true
+	}
+	return f.marks
+}
+
+// anchorOffset returns where to put the origin of mark relative to the
+// origin of base so that their GPOS anchors meet, in font units with y up.
+func (f *Font) anchorOffset(base, mark truetype.Index) (dx, dy int, ok bool) {
+	for _, t := range f.markBases() {
+		m, ok := t.marks[mark]
+		if !ok {
+			continue
+		}
+		anchors, ok := t.bases[base]
+		if !ok || m.class >= len(anchors) || !anchors[m.class].ok {
+			continue
+		}
+		a := anchors[m.class]
+		return a.x - m.anchor.x, a.y - m.anchor.y, true
+	}
+	return 0, 0, false
+}
+
+// glyphBounds returns the ink bounds of a glyph in pixels, with y up.
+func (f *Font) glyphBounds(glyph truetype.Index) (xmin, ymin, xmax, ymax float64, err error) {
+	if err := f.loadGlyph(glyph); err != nil {
+		return 0, 0, 0, 0, err
+	}
+	b := f.glyphBuf.B
+	return fUnitsToFloat64(b.XMin), fUnitsToFloat64(b.YMin), fUnitsToFloat64(b.XMax), fUnitsToFloat64(b.YMax), nil
+}
+
+// attachMarks gives the mark glyphs among glyphs, which are in logical order
+// with clusters indexing into text, a zero advance and an offset that puts
+// them on their base. The GPOS mark feature is used when the font has an
+// anchor for the pair. Otherwise the mark is centered over the base's ink
+// and, if it would collide with the base or with an earlier mark, moved
+// clear of it.
+func (f *Font) attachMarks(text []rune, glyphs []ShapedGlyph, rtl bool) {
+	gap := f.EmSize() / 25
+	base := -1
+	var top, bottom float64
+	for i := range glyphs {
+		g := &glyphs[i]
+		if !f.isMark(text[g.Cluster], g.Glyph) {
+			base = i
+			if _, ymin, _, ymax, err := f.glyphBounds(g.Glyph); err == nil {
+				top, bottom = ymax, ymin
+			}
+			continue
+		}
+		g.XAdvance = 0
+		if base < 0 {
+			continue
+		}
+		b := &glyphs[base]
+		// Left to right, the pen has moved past the base by the time it
+		// reaches the mark; right to left, marks are drawn before their base
+		// at its origin.
+		penX := b.XAdvance
+		if rtl {
+			penX = 0
+		}
+		if dx, dy, ok := f.anchorOffset(b.Glyph, g.Glyph); ok {
+			g.XOffset = b.XOffset + f.fUnitsToPixels(dx) - penX
+			g.YOffset = b.YOffset - f.fUnitsToPixels(dy)
+			continue
+		}
+		bxmin, _, bxmax, _, err := f.glyphBounds(b.Glyph)
+		if err != nil {
+			continue
+		}
+		mxmin, mymin, mxmax, mymax, err := f.glyphBounds(g.Glyph)
+		if err != nil {
+			continue
+		}
+		g.XOffset = b.XOffset + (bxmin+bxmax)/2 - (mxmin+mxmax)/2 - penX
+		dy := 0.0
+		switch {
+		case mymin >= 0 && mymin < top+gap:
+			dy = top + gap - mymin
+			top = mymax + dy
+		case mymin >= 0:
+			top = mymax
+		case mymax <= 0 && mymax > bottom-gap:
+			dy = bottom - gap - mymax
+			bottom = mymin + dy
+		case mymax <= 0:
+			bottom = mymin
+		}
+		g.YOffset = b.YOffset - dy
+	}
+}
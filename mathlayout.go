@@ -0,0 +1,195 @@
+package filmore
+
+import (
+	"log"
+	"math"
+)
+
+// Simple layout of mathematical formulas. Each function takes paths laid out
+// with their origin at 0, 0, as CreateTextPath(s, 0, 0) makes them, and
+// places them around the origin x, y on the baseline. Positions are taken
+// from the constants of the font's MATH table when it has one, as STIX, Cambria
+// and Latin Modern Math do, and otherwise from values typical of text fonts.
+
+// mathConstants holds the MATH table constants filmore uses, in font units.
+type mathConstants struct {
+	scriptPercent, scriptScriptPercent int
+
+	axisHeight int
+
+	subscriptShiftDown, subscriptTopMax, subscriptBaselineDropMin        int
+	superscriptShiftUp, superscriptBottomMin, superscriptBaselineDropMax int
+	spaceAfterScript                                                     int
+
+	numeratorShiftUp, denominatorShiftDown   int
+	numeratorGapMin, denominatorGapMin       int
+	fractionRuleThickness                    int
+	radicalVerticalGap, radicalRuleThickness int
+}
+
+// mathConstants returns the font's MATH constants for text style formulas.
+func (f *Font) mathConstants() mathConstants {
+	table := f.table("MATH")
+	if c := sub(table, u16(table, 4)); u16(table, 4) != 0 && len(c) > 8+4*51 {
+		// Apart from the first four, each constant is a value record of a
+		// value and a device table offset.
+		v := func(i int) int { return i16(c, 8+4*i) }
+		return mathConstants{
+			scriptPercent:              i16(c, 0),
+			scriptScriptPercent:        i16(c, 2),
+			axisHeight:                 v(1),
+			subscriptShiftDown:         v(4),
+			subscriptTopMax:            v(5),
+			subscriptBaselineDropMin:   v(6),
+			superscriptShiftUp:         v(7),
+			superscriptBottomMin:       v(9),
+			superscriptBaselineDropMax: v(10),
+			spaceAfterScript:           v(13),
+			numeratorShiftUp:           v(28),
+			denominatorShiftDown:       v(30),
+			numeratorGapMin:            v(32),
+			fractionRuleThickness:      v(34),
+			denominatorGapMin:          v(35),
+			radicalVerticalGap:         v(45),
+			radicalRuleThickness:       v(47),
+		}
+	}
+	em := int(f.font.FUnitsPerEm())
+	rule := em / 20
+	return mathConstants{
+		scriptPercent:              70,
+		scriptScriptPercent:        50,
+		axisHeight:                 em / 4,
+		subscriptShiftDown:         em / 5,
+		subscriptTopMax:            em * 2 / 5,
+		subscriptBaselineDropMin:   em / 20,
+		superscriptShiftUp:         em * 9 / 25,
+		superscriptBottomMin:       em / 10,
+		superscriptBaselineDropMax: em * 2 / 5,
+		spaceAfterScript:           em / 20,
+		numeratorShiftUp:           em * 2 / 5,
+		denominatorShiftDown:       em * 7 / 20,
+		numeratorGapMin:            rule,
+		fractionRuleThickness:      rule,
+		denominatorGapMin:          rule,
+		radicalVerticalGap:         rule * 5 / 4,
+		radicalRuleThickness:       rule,
+	}
+}
+
+// ScriptScale returns the factor by which scripts are scaled down at the
+// given depth of nesting: 1 for scripts on the main text and 2 or more for
+// scripts on scripts.
+func (f *Font) ScriptScale(depth int) float64 {
+	c := f.mathConstants()
+	switch {
+	case depth <= 0:
+		return 1
+	case depth == 1:
+		return float64(c.scriptPercent) / 100
+	}
+	return float64(c.scriptScriptPercent) / 100
+}
+
+// inkExtent returns the horizontal ink bounds of a path along with its height
+// above and depth below the baseline, y = 0.
+func inkExtent(p *TextPath) (minX, maxX, height, depth float64) {
+	minX, minY, maxX, maxY, ok := polylineBounds(p.Flatten(defaultTolerance))
+	if !ok {
+		return 0, 0, 0, 0
+	}
+	return minX, maxX, -minY, maxY
+}
+
+// placed returns p scaled by s about its origin and moved to x, y.
+func placed(p *TextPath, x, y, s float64) []Op {
+	q := p.mapPoints(func(px, py float64) (float64, float64) {
+		return x + px*s, y + py*s
+	})
+	return q.PathOps
+}
+
+// Fraction returns num stacked over den with a fraction bar between them on
+// the font's math axis. The bar starts at x and is as long as the wider of
+// the two, which are each centered on it; that length is the Width of the
+// result.
+func (f *Font) Fraction(num, den *TextPath, x, y float64) TextPath {
+	c := f.mathConstants()
+	px := f.fUnitsToPixels
+	axis, t := px(c.axisHeight), px(c.fractionRuleThickness)
+	w := math.Max(num.Width, den.Width)
+	_, _, _, numDepth := inkExtent(num)
+	_, _, denHeight, _ := inkExtent(den)
+	numShift := math.Max(px(c.numeratorShiftUp), axis+t/2+px(c.numeratorGapMin)+numDepth)
+	denShift := math.Max(px(c.denominatorShiftDown), px(c.denominatorGapMin)+t/2-axis+denHeight)
+
+	result := rectPath(x, y-axis-t/2, x+w, y-axis+t/2)
+	result.PathOps = append(result.PathOps, placed(num, x+(w-num.Width)/2, y-numShift, 1)...)
+	result.PathOps = append(result.PathOps, placed(den, x+(w-den.Width)/2, y+denShift, 1)...)
+	result.Width = w
+	return result
+}
+
+// Radical returns radicand under a radical sign starting at x, with the
+// sign's bar extended over it. The sign is the font's √ stretched to the
+// height of the radicand.
+func (f *Font) Radical(radicand *TextPath, x, y float64) TextPath {
+	c := f.mathConstants()
+	px := f.fUnitsToPixels
+	t, gap := px(c.radicalRuleThickness), px(c.radicalVerticalGap)
+	_, _, height, depth := inkExtent(radicand)
+	top, bottom := y-height-gap-t, y+depth
+
+	var sign TextPath
+	glyph := f.font.Index('√')
+	if err := f.appendGlyphPath(glyph, 0, 0, &sign); err != nil {
+		log.Println(err)
+	}
+	advance := fUnitsToFloat64(f.font.HMetric(f.scale, glyph).AdvanceWidth)
+	_, signRight, signHeight, signDepth := inkExtent(&sign)
+	// Stretch the sign vertically so its ink runs from the top of the bar to
+	// the bottom of the radicand.
+	sy := 1.0
+	if signHeight+signDepth > 0 {
+		sy = (bottom - top) / (signHeight + signDepth)
+	}
+	result := sign.mapPoints(func(gx, gy float64) (float64, float64) {
+		return x + gx, top + (gy+signHeight)*sy
+	})
+	w := advance + radicand.Width
+	bar := rectPath(x+signRight-t/2, top, x+w, top+t)
+	result.PathOps = append(result.PathOps, bar.PathOps...)
+	result.PathOps = append(result.PathOps, placed(radicand, x+advance, y, 1)...)
+	result.Width = w
+	return result
+}
+
+// Scripts returns base with a superscript and a subscript attached after it,
+// scaled down to script size. Either script may be nil. The scripts
+// themselves should be laid out at full size.
+func (f *Font) Scripts(base, superscript, subscript *TextPath, x, y float64) TextPath {
+	c := f.mathConstants()
+	px := f.fUnitsToPixels
+	s := f.ScriptScale(1)
+	_, _, baseHeight, baseDepth := inkExtent(base)
+
+	result := TextPath{PathOps: placed(base, x, y, 1)}
+	scriptX := x + base.Width
+	scriptWidth := 0.0
+	if superscript != nil {
+		_, _, _, supDepth := inkExtent(superscript)
+		shift := math.Max(px(c.superscriptShiftUp), baseHeight-px(c.superscriptBaselineDropMax))
+		shift = math.Max(shift, px(c.superscriptBottomMin)+supDepth*s)
+		result.PathOps = append(result.PathOps, placed(superscript, scriptX, y-shift, s)...)
+		scriptWidth = superscript.Width * s
+	}
+	if subscript != nil {
+		_, _, subHeight, _ := inkExtent(subscript)
+		shift := math.Max(px(c.subscriptShiftDown), baseDepth+px(c.subscriptBaselineDropMin))
+		shift = math.Max(shift, subHeight*s-px(c.subscriptTopMax))
+		result.PathOps = append(result.PathOps, placed(subscript, scriptX, y+shift, s)...)
+		scriptWidth = math.Max(scriptWidth, subscript.Width*s)
+	}
+	result.Width = base.Width + scriptWidth + px(c.spaceAfterScript)
+	return result
+}
@@ -0,0 +1,61 @@
+package filmore
+
+import "math"
+
+// Measure returns the width of s as CreateTextPath would lay it out, along
+// with the font's ascent and descent, all in pixels. It only looks at the
+// font's metrics, without loading any outlines, so it is much cheaper than
+// creating the path when only the size is needed.
+func (f *Font) Measure(s string) (width, ascent, descent float64) {
+	for _, a := range f.Advances(s) {
+		width += a
+	}
+	return width, f.Ascent(), f.Descent()
+}
+
+// Advances returns how far each rune of s moves the pen, in pixels, with
+// kerning against the following rune included, so that they add up to the
+// width Measure returns. Runes that draw nothing of their own, such as
+// combining marks and the joiners in emoji sequences, have no advance.
+func (f *Font) Advances(s string) []float64 {
+	runes := []rune(s)
+	advances := make([]float64, len(runes))
+	for _, g := range f.shapeAdvances(runes, false) {
+		if o, ok := f.overrides[runes[g.Cluster]]; ok {
+			advances[g.Cluster] += o.advance
+		} else {
+			advances[g.Cluster] += g.XAdvance
+		}
+	}
+	return advances
+}
+
+// CaretPositions returns the x offset, from the start of s, of the caret
+// before each rune of s, followed by that of the caret after the last rune,
+// so that there are one more positions than runes. Kerning is accounted for,
+// and runes with no advance of their own, such as combining marks, share the
+// position of the rune after them.
+func (f *Font) CaretPositions(s string) []float64 {
+	advances := f.Advances(s)
+	positions := make([]float64, len(advances)+1)
+	for i, a := range advances {
+		positions[i+1] = positions[i] + a
+	}
+	return positions
+}
+
+// IndexAtOffset returns the index, in runes, of the rune boundary of s nearest
+// to the offset x from the start of s, such as for placing the caret where
+// the user clicked. The result ranges from 0, before the first rune, to the
+// number of runes in s. Boundaries that would split a rune from the combining
+// marks or joined emoji that follow it are never returned.
+func (f *Font) IndexAtOffset(s string, x float64) int {
+	positions := f.CaretPositions(s)
+	best := 0
+	for i, p := range positions {
+		if math.Abs(p-x) <= math.Abs(positions[best]-x) {
+			best = i
+		}
+	}
+	return best
+}
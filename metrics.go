@@ -0,0 +1,230 @@
+package filmore
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// FontMetrics holds the advance widths and kerning pairs of a font without
+// its outlines, as found in the AFM and PFM files that accompany Type 1
+// fonts. It is enough to measure and break text when the outlines are not
+// available or not needed.
+type FontMetrics struct {
+	// widths and kerning are in units of 1/unitsPerEm of the em.
+	widths     map[rune]float64
+	kerning    map[[2]rune]float64
+	unitsPerEm int
+	scale      int32
+}
+
+func newFontMetrics(fontSize int) *FontMetrics {
+	return &FontMetrics{map[rune]float64{}, map[[2]rune]float64{}, 1000, ttscale(fontSize)}
+}
+
+func (m *FontMetrics) toPixels(x float64) float64 {
+	return x * float64(m.scale) / 64 / float64(m.unitsPerEm)
+}
+
+// Advance returns the advance width of r in pixels, or 0 if the metrics do
+// not cover r.
+func (m *FontMetrics) Advance(r rune) float64 {
+	return m.toPixels(m.widths[r])
+}
+
+// Kerning returns the adjustment in pixels to the advance of a when it is
+// followed by b.
+func (m *FontMetrics) Kerning(a, b rune) float64 {
+	return m.toPixels(m.kerning[[2]rune{a, b}])
+}
+
+// Width returns the width in pixels of s set in the font, with kerning, as
+// CreateTextPath would report it.
+func (m *FontMetrics) Width(s string) float64 {
+	w := 0.0
+	prev := rune(-1)
+	for _, r := range s {
+		w += m.widths[r]
+		if prev >= 0 {
+			w += m.kerning[[2]rune{prev, r}]
+		}
+		prev = r
+	}
+	return m.toPixels(w)
+}
+
+// NewAFMMetrics loads the metrics of a font from an Adobe Font Metrics file.
+// Characters are identified by their glyph names, which are mapped to
+// Unicode for the standard Latin names and names of the form uniXXXX and
+// uXXXX[XX].
+func NewAFMMetrics(afmData []byte, fontSize int) (*FontMetrics, error) {
+	m := newFontMetrics(fontSize)
+	names := map[string]rune{}
+	section := ""
+	scanner := bufio.NewScanner(bytes.NewReader(afmData))
+	for line := 1; scanner.Scan(); line++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		switch fields[0] {
+		case "StartCharMetrics", "StartKernPairs", "StartKernPairs0":
+			section = fields[0]
+			continue
+		case "EndCharMetrics", "EndKernPairs":
+			section = ""
+			continue
+		}
+		switch section {
+		case "StartCharMetrics":
+			code, width, name := -1, 0.0, ""
+			for _, item := range strings.Split(scanner.Text(), ";") {
+				kv := strings.Fields(item)
+				if len(kv) < 2 {
+					continue
+				}
+				var err error
+				switch kv[0] {
+				case "C":
+					code, err = strconv.Atoi(kv[1])
+				case "WX":
+					// Widths, like kerning amounts, may be fractional.
+					width, err = strconv.ParseFloat(kv[1], 64)
+				case "N":
+					name = kv[1]
+				}
+				if err != nil {
+					return nil, fmt.Errorf("filmore: AFM line %d: %v", line, err)
+				}
+			}
+			r, ok := glyphNameRune(name)
+			if !ok && code >= 0x20 && code < 0x7f && code != '\'' && code != '`' {
+				// Adobe StandardEncoding agrees with ASCII here.
+				r, ok = rune(code), true
+			}
+			if ok {
+				m.widths[r] = width
+				names[name] = r
+			}
+		case "StartKernPairs", "StartKernPairs0":
+			if fields[0] != "KPX" || len(fields) < 4 {
+				continue
+			}
+			k, err := strconv.ParseFloat(fields[3], 64)
+			if err != nil {
+				return nil, fmt.Errorf("filmore: AFM line %d: %v", line, err)
+			}
+			a, okA := names[fields[1]]
+			b, okB := names[fields[2]]
+			if okA && okB {
+				m.kerning[[2]rune{a, b}] = k
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(m.widths) == 0 {
+		return nil, fmt.Errorf("filmore: no character metrics in AFM file")
+	}
+	return m, nil
+}
+
+// NewPFMMetrics loads the metrics of a font from a Printer Font Metrics file,
+// the Windows counterpart of an AFM file. Character codes are taken to be in
+// the Windows-1252 encoding.
+func NewPFMMetrics(pfmData []byte, fontSize int) (*FontMetrics, error) {
+	le16 := func(i int) int { return u8(pfmData, i) | u8(pfmData, i+1)<<8 }
+	le32 := func(i int) int { return le16(i) | le16(i+2)<<16 }
+	if len(pfmData) < 147 || le32(2) != len(pfmData) {
+		return nil, fmt.Errorf("filmore: not a PFM file")
+	}
+	m := newFontMetrics(fontSize)
+	if ext := le32(119); ext != 0 && le16(ext+12) != 0 {
+		m.unitsPerEm = le16(ext + 12)
+	}
+	first, last := u8(pfmData, 95), u8(pfmData, 96)
+	extents := le32(123)
+	if extents == 0 {
+		return nil, fmt.Errorf("filmore: PFM file has no width table")
+	}
+	for c := first; c <= last; c++ {
+		m.widths[cp1252Rune(c)] = float64(le16(extents + 2*(c-first)))
+	}
+	if kern := le32(131); kern != 0 {
+		for i, n := 0, le16(kern); i < n; i++ {
+			rec := kern + 2 + 4*i
+			a, b := cp1252Rune(u8(pfmData, rec)), cp1252Rune(u8(pfmData, rec+1))
+			m.kerning[[2]rune{a, b}] = float64(int16(le16(rec + 2)))
+		}
+	}
+	return m, nil
+}
+
+// cp1252High maps the bytes 0x80 to 0x9f of Windows-1252 to Unicode. The
+// rest of the encoding agrees with Latin-1.
+var cp1252High = [32]rune{
+	0x20ac, 0x81, 0x201a, 0x0192, 0x201e, 0x2026, 0x2020, 0x2021,
+	0x02c6, 0x2030, 0x0160, 0x2039, 0x0152, 0x8d, 0x017d, 0x8f,
+	0x90, 0x2018, 0x2019, 0x201c, 0x201d, 0x2022, 0x2013, 0x2014,
+	0x02dc, 0x2122, 0x0161, 0x203a, 0x0153, 0x9d, 0x017e, 0x0178,
+}
+
+func cp1252Rune(c int) rune {
+	if c >= 0x80 && c < 0xa0 {
+		return cp1252High[c-0x80]
+	}
+	return rune(c)
+}
+
+// glyphNames maps the standard glyph names of Latin text fonts that are not
+// a single letter or digit to Unicode.
+var glyphNames = map[string]rune{
+	"space": ' ', "exclam": '!', "quotedbl": '"', "numbersign": '#',
+	"dollar": '$', "percent": '%', "ampersand": '&', "quotesingle": '\'',
+	"parenleft": '(', "parenright": ')', "asterisk": '*', "plus": '+',
+	"comma": ',', "hyphen": '-', "period": '.', "slash": '/',
+	"zero": '0', "one": '1', "two": '2', "three": '3', "four": '4',
+	"five": '5', "six": '6', "seven": '7', "eight": '8', "nine": '9',
+	"colon": ':', "semicolon": ';', "less": '<', "equal": '=',
+	"greater": '>', "question": '?', "at": '@', "bracketleft": '[',
+	"backslash": '\\', "bracketright": ']', "asciicircum": '^',
+	"underscore": '_', "grave": '`', "braceleft": '{', "bar": '|',
+	"braceright": '}', "asciitilde": '~',
+	"quoteleft": '‘', "quoteright": '’',
+	"quotedblleft": '“', "quotedblright": '”',
+	"quotesinglbase": '‚', "quotedblbase": '„',
+	"endash": '–', "emdash": '—', "bullet": '•',
+	"ellipsis": '…', "dagger": '†', "daggerdbl": '‡',
+	"guilsinglleft": '‹', "guilsinglright": '›',
+	"guillemotleft": '«', "guillemotright": '»',
+	"exclamdown": '¡', "questiondown": '¿', "cent": '¢',
+	"sterling": '£', "yen": '¥', "Euro": '€',
+	"section": '§', "paragraph": '¶', "copyright": '©',
+	"registered": '®', "trademark": '™', "degree": '°',
+	"periodcentered": '·', "multiply": '×', "divide": '÷',
+	"germandbls": 'ß', "dotlessi": 'ı', "fi": 'ﬁ',
+	"fl": 'ﬂ', "florin": 'ƒ', "perthousand": '‰',
+}
+
+// glyphNameRune returns the character named by a glyph name.
+func glyphNameRune(name string) (rune, bool) {
+	if r, ok := glyphNames[name]; ok {
+		return r, true
+	}
+	if len(name) == 1 && (name[0] >= 'A' && name[0] <= 'Z' || name[0] >= 'a' && name[0] <= 'z') {
+		return rune(name[0]), true
+	}
+	for _, prefix := range []string{"uni", "u"} {
+		hex := strings.TrimPrefix(name, prefix)
+		if hex == name || len(hex) < 4 || len(hex) > 6 {
+			continue
+		}
+		if v, err := strconv.ParseUint(hex, 16, 32); err == nil {
+			return rune(v), true
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,28 @@
+package filmore
+
+// CreateMirroredTextPath is like CreateTextPath, but mirrors the text left to
+// right, as for engraving the back of glass or acrylic to be read from the
+// front. The text keeps its advance box, running from x to x + Width, so it
+// measures and aligns just as unmirrored text does.
+func (f *Font) CreateMirroredTextPath(s string, x, y float64) TextPath {
+	p := f.CreateTextPath(s, x, y)
+	p = p.mapPoints(func(px, py float64) (float64, float64) {
+		return 2*x + p.Width - px, py
+	})
+	p.Reverse()
+	return p
+}
+
+// CreateFlippedTextPath is like CreateTextPath, but turns the text upside
+// down by mirroring it top to bottom. The text is flipped within the line,
+// between the font's ascent and descent, so it keeps the same baseline and
+// vertical extent as unflipped text.
+func (f *Font) CreateFlippedTextPath(s string, x, y float64) TextPath {
+	p := f.CreateTextPath(s, x, y)
+	axis := y - (f.Ascent()-f.Descent())/2
+	p = p.mapPoints(func(px, py float64) (float64, float64) {
+		return px, 2*axis - py
+	})
+	p.Reverse()
+	return p
+}
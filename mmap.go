@@ -0,0 +1,44 @@
+package filmore
+
+import "os"
+
+// NewFontFromFileMapped is like NewFontFromFile, but maps the file into
+// memory instead of reading it onto the heap, which saves copying large
+// fonts such as CJK ones. The font's tables are parsed from the mapped
+// region, so the file must not be modified while the font is in use. Call
+// Close to unmap it. On systems without mmap, the file is read as by
+// NewFontFromFile.
+func NewFontFromFileMapped(filename string, fontSize int) (*Font, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	info, err := file.Stat()
+	if err != nil {
+		return nil, err
+	}
+	data, unmap, err := mapFile(file, info.Size())
+	if err != nil {
+		return nil, err
+	}
+	f, err := NewFont(data, fontSize)
+	if err != nil {
+		unmap()
+		return nil, err
+	}
+	f.unmap = unmap
+	return f, nil
+}
+
+// Close releases the memory mapping of a font loaded with
+// NewFontFromFileMapped. The font, and the slice returned by its Data method,
+// must not be used afterwards. For other fonts Close does nothing.
+func (f *Font) Close() error {
+	if f.unmap == nil {
+		return nil
+	}
+	err := f.unmap()
+	f.unmap = nil
+	return err
+}
@@ -0,0 +1,17 @@
+//go:build !(darwin || dragonfly || freebsd || linux || netbsd || openbsd)
+// +build !darwin,!dragonfly,!freebsd,!linux,!netbsd,!openbsd
+
+package filmore
+
+import (
+	"io"
+	"os"
+)
+
+func mapFile(file *os.File, size int64) ([]byte, func() error, error) {
+	data := make([]byte, size)
+	if _, err := io.ReadFull(file, data); err != nil {
+		return nil, nil, err
+	}
+	return data, func() error { return nil }, nil
+}
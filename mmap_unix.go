@@ -0,0 +1,20 @@
+//go:build darwin || dragonfly || freebsd || linux || netbsd || openbsd
+// +build darwin dragonfly freebsd linux netbsd openbsd
+
+package filmore
+
+import (
+	"os"
+	"syscall"
+)
+
+func mapFile(file *os.File, size int64) ([]byte, func() error, error) {
+	if size == 0 || int64(int(size)) != size {
+		return nil, nil, &os.PathError{Op: "mmap", Path: file.Name(), Err: syscall.EINVAL}
+	}
+	data, err := syscall.Mmap(int(file.Fd()), 0, int(size), syscall.PROT_READ, syscall.MAP_SHARED)
+	if err != nil {
+		return nil, nil, &os.PathError{Op: "mmap", Path: file.Name(), Err: err}
+	}
+	return data, func() error { return syscall.Munmap(data) }, nil
+}
@@ -0,0 +1,224 @@
+package filmore
+
+import (
+	"math"
+	"sort"
+)
+
+// A Morph interpolates between two paths of any shapes, such as the same
+// string set in different fonts or weights, for animated transitions. Unlike
+// BlendTextPath it doesn't need compatible outlines: the correspondence
+// between the paths is worked out once by NewMorph, and each frame is then
+// cheap to draw with At.
+type Morph struct {
+	// pairs holds corresponding contours of the two paths, resampled to
+	// the same number of points.
+	pairs          [][2][]Point
+	widthA, widthB float64
+}
+
+// morphSpacing is roughly how far apart, in pixels, NewMorph samples the
+// contours, and morphMinPoints and morphMaxPoints bound how many samples a
+// contour gets.
+const (
+	morphSpacing   = 0.5
+	morphMinPoints = 16
+	morphMaxPoints = 2048
+)
+
+// NewMorph prepares to interpolate between a and b. If both paths have
+// Sources for the same number of glyphs, as from TextOptions.Attribute, the
+// glyphs are paired in order; otherwise the paths are matched as a whole.
+// Within each pair, contours are matched by position, outer contours to
+// outer contours and counters to counters, and each pair of contours is
+// sampled at matching fractions of their lengths, starting from the closest
+// points. Contours left without a partner shrink away to, or grow from, their
+// centers.
+func NewMorph(a, b *TextPath) *Morph {
+	m := &Morph{widthA: a.Width, widthB: b.Width}
+	groupsA, groupsB := morphGroups(a), morphGroups(b)
+	if len(groupsA) == 0 || len(groupsA) != len(groupsB) {
+		groupsA, groupsB = [][][]Point{a.Flatten(defaultTolerance)}, [][][]Point{b.Flatten(defaultTolerance)}
+	}
+	for i := range groupsA {
+		m.matchContours(groupsA[i], groupsB[i])
+	}
+	return m
+}
+
+// morphGroups returns the flattened contours of each glyph of p, or nil if p
+// has no Sources.
+func morphGroups(p *TextPath) [][][]Point {
+	var groups [][][]Point
+	for _, src := range p.Sources {
+		glyph := TextPath{PathOps: p.PathOps[src.Start:src.End]}
+		groups = append(groups, glyph.Flatten(defaultTolerance))
+	}
+	return groups
+}
+
+// matchContours adds pairs to m for the contours of a glyph in each path.
+func (m *Morph) matchContours(as, bs [][]Point) {
+	type contour struct {
+		pts    []Point
+		area   float64
+		center Point
+	}
+	describe := func(polys [][]Point) []contour {
+		var cs []contour
+		for _, pts := range polys {
+			if len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+				pts = pts[:len(pts)-1]
+			}
+			if len(pts) == 0 {
+				continue
+			}
+			area, center := polygonCenter(pts)
+			cs = append(cs, contour{pts, area, center})
+		}
+		// Match the biggest contours first.
+		sort.SliceStable(cs, func(i, j int) bool { return math.Abs(cs[i].area) > math.Abs(cs[j].area) })
+		return cs
+	}
+	ca, cb := describe(as), describe(bs)
+	used := make([]bool, len(cb))
+	for _, c := range ca {
+		best, bestDist := -1, math.Inf(1)
+		for j, d := range cb {
+			if used[j] {
+				continue
+			}
+			dist := math.Hypot(c.center.X-d.center.X, c.center.Y-d.center.Y)
+			if (c.area < 0) != (d.area < 0) {
+				// Prefer contours of the same kind.
+				dist += 1e6
+			}
+			if dist < bestDist {
+				best, bestDist = j, dist
+			}
+		}
+		if best < 0 {
+			m.pairs = append(m.pairs, morphPair(c.pts, []Point{c.center}))
+			continue
+		}
+		used[best] = true
+		m.pairs = append(m.pairs, morphPair(c.pts, cb[best].pts))
+	}
+	for j, d := range cb {
+		if !used[j] {
+			m.pairs = append(m.pairs, morphPair([]Point{d.center}, d.pts))
+		}
+	}
+}
+
+// morphPair resamples the closed polygons a and b to corresponding points,
+// wound the same way and starting from their closest points.
+func morphPair(a, b []Point) [2][]Point {
+	n := int(math.Ceil(math.Max(polygonLength(a), polygonLength(b)) / morphSpacing))
+	n = int(math.Max(morphMinPoints, math.Min(morphMaxPoints, float64(n))))
+	ra, rb := resamplePolygon(a, n), resamplePolygon(b, n)
+	areaA, _ := polygonCenter(a)
+	areaB, _ := polygonCenter(b)
+	if len(a) > 2 && len(b) > 2 && (areaA < 0) != (areaB < 0) {
+		for i, j := 0, n-1; i < j; i, j = i+1, j-1 {
+			rb[i], rb[j] = rb[j], rb[i]
+		}
+	}
+	best, bestCost := 0, math.Inf(1)
+	for k := 0; k < n; k++ {
+		cost := 0.0
+		for i := 0; i < n && cost < bestCost; i++ {
+			q := rb[(i+k)%n]
+			cost += (ra[i].X-q.X)*(ra[i].X-q.X) + (ra[i].Y-q.Y)*(ra[i].Y-q.Y)
+		}
+		if cost < bestCost {
+			best, bestCost = k, cost
+		}
+	}
+	return [2][]Point{ra, append(rb[best:], rb[:best]...)}
+}
+
+// polygonCenter returns the signed area of the closed polygon pts, positive
+// when wound like an outer contour, and its centroid, or the average of its
+// points if it has no area.
+func polygonCenter(pts []Point) (area float64, center Point) {
+	var sx, sy float64
+	for i, a := range pts {
+		b := pts[(i+1)%len(pts)]
+		c := cross(a.X, a.Y, b.X, b.Y)
+		area += c / 2
+		sx += (a.X + b.X) * c
+		sy += (a.Y + b.Y) * c
+	}
+	if math.Abs(area) > 1e-12 {
+		return area, Point{sx / (6 * area), sy / (6 * area)}
+	}
+	for _, p := range pts {
+		center.X += p.X / float64(len(pts))
+		center.Y += p.Y / float64(len(pts))
+	}
+	return area, center
+}
+
+// polygonLength returns the perimeter of the closed polygon pts.
+func polygonLength(pts []Point) float64 {
+	l := 0.0
+	for i, a := range pts {
+		b := pts[(i+1)%len(pts)]
+		l += math.Hypot(b.X-a.X, b.Y-a.Y)
+	}
+	return l
+}
+
+// resamplePolygon returns n points spaced evenly around the closed polygon
+// pts, starting from its first point.
+func resamplePolygon(pts []Point, n int) []Point {
+	result := make([]Point, 0, n)
+	total := polygonLength(pts)
+	if total == 0 {
+		for len(result) < n {
+			result = append(result, pts[0])
+		}
+		return result
+	}
+	step := total / float64(n)
+	i, walked := 0, 0.0
+	for k := 0; k < n; k++ {
+		want := float64(k) * step
+		a, b := pts[i], pts[(i+1)%len(pts)]
+		l := math.Hypot(b.X-a.X, b.Y-a.Y)
+		for walked+l < want && i < len(pts)-1 {
+			walked += l
+			i++
+			a, b = pts[i], pts[(i+1)%len(pts)]
+			l = math.Hypot(b.X-a.X, b.Y-a.Y)
+		}
+		t := 0.0
+		if l > 0 {
+			t = math.Min(1, (want-walked)/l)
+		}
+		result = append(result, Point{a.X + t*(b.X-a.X), a.Y + t*(b.Y-a.Y)})
+	}
+	return result
+}
+
+// At returns the path at stage t of the morph: t = 0 gives the first path
+// passed to NewMorph, t = 1 the second, and values in between interpolate,
+// as do the widths. The result is made of straight segments, each contour
+// closed with a ClosePath.
+func (m *Morph) At(t float64) TextPath {
+	result := TextPath{Width: lerp(m.widthA, m.widthB, t)}
+	for _, pair := range m.pairs {
+		for i, a := range pair[0] {
+			b := pair[1][i]
+			x, y := lerp(a.X, b.X, t), lerp(a.Y, b.Y, t)
+			if i == 0 {
+				result.MoveTo(x, y)
+			} else {
+				result.LineTo(x, y)
+			}
+		}
+		result.ClosePath()
+	}
+	return result
+}
@@ -0,0 +1,97 @@
+package filmore
+
+import "unicode/utf16"
+
+// Name IDs of the name table entries filmore reads.
+const (
+	nameFamily        = 1
+	nameSubfamily     = 2
+	nameFullName      = 4
+	nameVersion       = 5
+	nameLicense       = 13
+	nameTypoFamily    = 16
+	nameTypoSubfamily = 17
+)
+
+// Family returns the name of the font's family, such as "Source Serif 4".
+func (f *Font) Family() string {
+	if s := nameString(f.table("name"), nameTypoFamily); s != "" {
+		return s
+	}
+	return nameString(f.table("name"), nameFamily)
+}
+
+// Style returns the name of the font's style within its family, such as
+// "Bold Italic" or "Semibold".
+func (f *Font) Style() string {
+	if s := nameString(f.table("name"), nameTypoSubfamily); s != "" {
+		return s
+	}
+	return nameString(f.table("name"), nameSubfamily)
+}
+
+// FullName returns the full name of the font, which usually combines its
+// family and style.
+func (f *Font) FullName() string {
+	return nameString(f.table("name"), nameFullName)
+}
+
+// Version returns the font's version string, such as "Version 2.37".
+func (f *Font) Version() string {
+	return nameString(f.table("name"), nameVersion)
+}
+
+// License returns the description of the font's license, or "" if the font
+// does not give one.
+func (f *Font) License() string {
+	return nameString(f.table("name"), nameLicense)
+}
+
+// nameString returns the string with the given name ID from a name table,
+// or "" if there is none. English Windows names are preferred, then other
+// Unicode names, then Macintosh Roman ones.
+func nameString(name []byte, id int) string {
+	strs := sub(name, u16(name, 4))
+	best, bestRank := "", 0
+	for i, n := 0, u16(name, 2); i < n; i++ {
+		rec := 6 + 12*i
+		if u16(name, rec+6) != id {
+			continue
+		}
+		platform, encoding, language := u16(name, rec), u16(name, rec+2), u16(name, rec+4)
+		off, length := u16(name, rec+10), u16(name, rec+8)
+		if off+length > len(strs) {
+			continue
+		}
+		b := strs[off : off+length]
+		rank := 0
+		switch {
+		case platform == 3 && (encoding == 1 || encoding == 10) && language == 0x409:
+			rank = 4
+		case platform == 3 && (encoding == 1 || encoding == 10), platform == 0:
+			rank = 3
+		case platform == 1 && encoding == 0:
+			rank = 2
+		}
+		if rank <= bestRank {
+			continue
+		}
+		if rank == 2 {
+			// Mac Roman agrees with Latin-1 for the ASCII names that matter
+			// here.
+			r := make([]rune, len(b))
+			for j, c := range b {
+				r[j] = rune(c)
+			}
+			best = string(r)
+		} else {
+			u := make([]uint16, len(b)/2)
+			for j := range u {
+				u[j] = uint16(u16(b, 2*j))
+			}
+			best = string(utf16.Decode(u))
+		}
+		bestRank = rank
+	}
+	return best
+}
@@ -0,0 +1,28 @@
+package filmore
+
+import "math"
+
+// slantExtra returns how much further than its advances text set with
+// glyphs leans out at its top, or at its bottom for a backward slant, when
+// slanted by angle as for TextOptions.Slant.
+func slantExtra(glyphs []fontGlyph, angle float64) float64 {
+	slope := math.Tan(angle)
+	extra := 0.0
+	for _, g := range glyphs {
+		reach := g.font.Ascent()
+		if slope < 0 {
+			reach = g.font.Descent()
+		}
+		extra = math.Max(extra, reach*math.Abs(slope))
+	}
+	return extra
+}
+
+// slantOps shears ops in place by angle as for TextOptions.Slant, about the
+// baseline at y.
+func slantOps(ops []Op, y, angle float64) {
+	slope := math.Tan(angle)
+	p := TextPath{PathOps: ops}
+	slanted := p.mapPoints(func(px, py float64) (float64, float64) { return px + (y-py)*slope, py })
+	copy(ops, slanted.PathOps)
+}
@@ -0,0 +1,95 @@
+package filmore
+
+import "math"
+
+// Offset returns p's outline moved outward by delta pixels, or inward if
+// delta is negative, for halos, padding and the tool radius compensation of
+// CNC routing. Corners that the outline moves away from are rounded, parts
+// thinner than twice an inward offset vanish, and wherever the moved outline
+// would cross itself the crossings are resolved, so the result is a clean
+// outline as from Combine.
+func (p *TextPath) Offset(delta float64) TextPath {
+	if delta == 0 {
+		return p.Union()
+	}
+	band := outlineBand(p.Flatten(offsetTolerance), math.Abs(delta))
+	if delta > 0 {
+		return p.Combine(BooleanUnion, &band)
+	}
+	return p.Subtract(&band)
+}
+
+// offsetTolerance is the flattening tolerance of the outline that Offset
+// moves. Only the moved edge of the band it adds or cuts away shows in the
+// result, so it can be coarser than that of the outline itself.
+const offsetTolerance = 2 * defaultTolerance
+
+// outlineBand returns the area within r of the outline made by closing each
+// of the polylines, as Combine does. It is made of a rectangle along each
+// segment and a round join on the outside of each corner, all wound like
+// outer contours so that they add up.
+func outlineBand(polys [][]Point, r float64) TextPath {
+	var band TextPath
+	sides := discSides(r)
+	for _, poly := range polys {
+		// Drop repeated points, which have no direction, including the
+		// end of a polyline that comes back to its start.
+		pts := poly[:1]
+		for _, pt := range poly[1:] {
+			if pt != pts[len(pts)-1] {
+				pts = append(pts, pt)
+			}
+		}
+		if len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+			pts = pts[:len(pts)-1]
+		}
+		n := len(pts)
+		if n == 1 {
+			band.appendDisc(pts[0], r, sides)
+			continue
+		}
+		for i, b := range pts {
+			a, c := pts[(i+n-1)%n], pts[(i+1)%n]
+			band.appendSegmentRect(b, c, r)
+			band.appendJoin(a, b, c, r, JoinRound, sides)
+		}
+	}
+	return band
+}
+
+// appendSegmentRect appends the rectangle within r of the segment ab, wound
+// like an outer contour.
+func (p *TextPath) appendSegmentRect(a, b Point, r float64) {
+	l := math.Hypot(b.X-a.X, b.Y-a.Y)
+	if l == 0 {
+		return
+	}
+	nx, ny := (b.Y-a.Y)/l*r, -(b.X-a.X)/l*r
+	// With y down, this goes around the rectangle clockwise on the page, like
+	// an outer contour.
+	p.MoveTo(a.X-nx, a.Y-ny)
+	p.LineTo(a.X+nx, a.Y+ny)
+	p.LineTo(b.X+nx, b.Y+ny)
+	p.LineTo(b.X-nx, b.Y-ny)
+	p.LineTo(a.X-nx, a.Y-ny)
+}
+
+// discSides returns how many sides a polygon needs to stay within the
+// default tolerance of a circle of radius r.
+func discSides(r float64) int {
+	if r <= defaultTolerance {
+		return 4
+	}
+	return int(math.Max(8, math.Ceil(math.Pi/math.Acos(1-defaultTolerance/r))))
+}
+
+// appendDisc appends a regular polygon with the given number of sides
+// approximating the circle of radius r about c, wound like an outer contour.
+func (p *TextPath) appendDisc(c Point, r float64, sides int) {
+	p.MoveTo(c.X+r, c.Y)
+	for i := 1; i < sides; i++ {
+		sin, cos := math.Sincos(2 * math.Pi * float64(i) / float64(sides))
+		p.LineTo(c.X+r*cos, c.Y+r*sin)
+	}
+	p.LineTo(c.X+r, c.Y)
+}
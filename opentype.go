@@ -0,0 +1,149 @@
+package filmore
+
+import "code.google.com/p/freetype-go/freetype/truetype"
+
+// Minimal readers for the OpenType layout tables (GSUB and GPOS). Features are
+// looked up by tag across all scripts and language systems, which is enough
+// for the handful of features filmore applies.
+
+// featureLookups returns the indices of the lookups used by the features with
+// the given tags in a GSUB or GPOS table, in lookup list order.
+func featureLookups(table []byte, tags ...string) []int {
+	features := sub(table, u16(table, 6))
+	seen := map[int]bool{}
+	for i, n := 0, u16(features, 0); i < n; i++ {
+		rec := 2 + 6*i
+		if rec+4 > len(features) {
+			break
+		}
+		tag := string(features[rec : rec+4])
+		match := false
+		for _, t := range tags {
+			match = match || t == tag
+		}
+		if !match {
+			continue
+		}
+		feature := sub(features, u16(features, rec+4))
+		for j, m := 0, u16(feature, 2); j < m; j++ {
+			seen[u16(feature, 4+2*j)] = true
+		}
+	}
+	var result []int
+	for i, n := 0, u16(sub(table, u16(table, 8)), 0); i < n; i++ {
+		if seen[i] {
+			result = append(result, i)
+		}
+	}
+	return result
+}
+
+// lookupSubtables returns the type of the given lookup in a GSUB or GPOS
+// table along with its subtables, with extension subtables resolved.
+// extensionType is 7 for GSUB and 9 for GPOS.
+func lookupSubtables(table []byte, index, extensionType int) (lookupType int, subtables [][]byte) {
+	lookups := sub(table, u16(table, 8))
+	lookup := sub(lookups, u16(lookups, 2+2*index))
+	lookupType = u16(lookup, 0)
+	for i, n := 0, u16(lookup, 4); i < n; i++ {
+		st := sub(lookup, u16(lookup, 6+2*i))
+		if lookupType == extensionType {
+			lookupType = u16(st, 2)
+			st = sub(st, u32(st, 4))
+		}
+		subtables = append(subtables, st)
+	}
+	return lookupType, subtables
+}
+
+// coverage calls fn with each glyph in a coverage table and its coverage
+// index.
+func coverage(table []byte, fn func(glyph truetype.Index, index int)) {
+	switch u16(table, 0) {
+	case 1:
+		for i, n := 0, u16(table, 2); i < n; i++ {
+			fn(truetype.Index(u16(table, 4+2*i)), i)
+		}
+	case 2:
+		for i, n := 0, u16(table, 2); i < n; i++ {
+			rec := 4 + 6*i
+			start, end, index := u16(table, rec), u16(table, rec+2), u16(table, rec+4)
+			for g := start; g <= end; g++ {
+				fn(truetype.Index(g), index+g-start)
+			}
+		}
+	}
+}
+
+// singleSubstitutions returns the glyph replacements made by the single
+// substitution lookups of the GSUB features with the given tags.
+func singleSubstitutions(gsub []byte, tags ...string) map[truetype.Index]truetype.Index {
+	result := map[truetype.Index]truetype.Index{}
+	for _, l := range featureLookups(gsub, tags...) {
+		lookupType, subtables := lookupSubtables(gsub, l, 7)
+		if lookupType != 1 {
+			continue
+		}
+		for _, st := range subtables {
+			cov := sub(st, u16(st, 2))
+			switch u16(st, 0) {
+			case 1:
+				delta := i16(st, 4)
+				coverage(cov, func(g truetype.Index, _ int) {
+					if _, ok := result[g]; !ok {
+						result[g] = truetype.Index(int(g) + delta)
+					}
+				})
+			case 2:
+				n := u16(st, 4)
+				coverage(cov, func(g truetype.Index, i int) {
+					if _, ok := result[g]; !ok && i < n {
+						result[g] = truetype.Index(u16(st, 6+2*i))
+					}
+				})
+			}
+		}
+	}
+	return result
+}
+
+// ligature returns the glyph that the ligature substitution lookups of the
+// GSUB features with the given tags put in place of exactly the sequence
+// glyphs, if there is one.
+func ligature(gsub []byte, glyphs []truetype.Index, tags ...string) (truetype.Index, bool) {
+	if len(glyphs) < 2 {
+		return 0, false
+	}
+	for _, l := range featureLookups(gsub, tags...) {
+		lookupType, subtables := lookupSubtables(gsub, l, 7)
+		if lookupType != 4 {
+			continue
+		}
+		for _, st := range subtables {
+			set := -1
+			coverage(sub(st, u16(st, 2)), func(g truetype.Index, i int) {
+				if g == glyphs[0] {
+					set = i
+				}
+			})
+			if set < 0 || u16(st, 0) != 1 || set >= u16(st, 4) {
+				continue
+			}
+			ligSet := sub(st, u16(st, 6+2*set))
+			for i, n := 0, u16(ligSet, 0); i < n; i++ {
+				lig := sub(ligSet, u16(ligSet, 2+2*i))
+				if u16(lig, 2) != len(glyphs) {
+					continue
+				}
+				match := true
+				for j, g := range glyphs[1:] {
+					match = match && truetype.Index(u16(lig, 4+2*j)) == g
+				}
+				if match {
+					return truetype.Index(u16(lig, 0)), true
+				}
+			}
+		}
+	}
+	return 0, false
+}
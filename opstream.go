@@ -0,0 +1,67 @@
+package filmore
+
+import (
+	"encoding/binary"
+	"io"
+	"math"
+)
+
+// Kinds of record in an op stream.
+const (
+	opStreamMoveTo = 0
+	opStreamLineTo = 1
+	opStreamQuadTo = 2
+	opStreamCubeTo = 3
+)
+
+// WriteOpStream writes the ops of p to w in a compact binary form meant for
+// drawing in a browser, such as from a web worker onto an OffscreenCanvas.
+// The stream is a series of records, one per op, each a kind byte followed
+// by little-endian float32 coordinates:
+//
+//	0 x y        moveTo(x, y)
+//	1 x y        lineTo(x, y)
+//	2 cx cy x y  quadraticCurveTo(cx, cy, x, y)
+//	3 cx1 cy1 cx2 cy2 x y  bezierCurveTo(cx1, cy1, cx2, cy2, x, y)
+//
+// so that a few lines of JavaScript can draw it:
+//
+//	const v = new DataView(buffer);
+//	for (let i = 0; i < v.byteLength; ) {
+//	  const kind = v.getUint8(i++), n = [2, 2, 4, 6][kind], c = [];
+//	  for (let j = 0; j < n; j++, i += 4) c.push(v.getFloat32(i, true));
+//	  if (kind == 0) ctx.moveTo(...c);
+//	  else if (kind == 1) ctx.lineTo(...c);
+//	  else if (kind == 2) ctx.quadraticCurveTo(...c);
+//	  else ctx.bezierCurveTo(...c);
+//	}
+func WriteOpStream(w io.Writer, p *TextPath) error {
+	buf := make([]byte, 0, 9*len(p.PathOps))
+	var word [4]byte
+	put := func(vs ...float64) {
+		for _, v := range vs {
+			binary.LittleEndian.PutUint32(word[:], math.Float32bits(float32(v)))
+			buf = append(buf, word[:]...)
+		}
+	}
+	for _, op := range p.PathOps {
+		switch op := op.(type) {
+		case MoveTo:
+			buf = append(buf, opStreamMoveTo)
+			put(op.x, op.y)
+		case LineTo, ClosePath:
+			// Decoders have no op for closing a contour; a line back to
+			// its start draws the same.
+			buf = append(buf, opStreamLineTo)
+			put(op.X(), op.Y())
+		case QuadCurveTo:
+			buf = append(buf, opStreamQuadTo)
+			put(op.cx, op.cy, op.x, op.y)
+		case CubicCurveTo:
+			buf = append(buf, opStreamCubeTo)
+			put(op.cx1, op.cy1, op.cx2, op.cy2, op.x, op.y)
+		}
+	}
+	_, err := w.Write(buf)
+	return err
+}
@@ -0,0 +1,23 @@
+package filmore
+
+type glyphOverride struct {
+	path    TextPath
+	advance float64
+}
+
+// SetGlyphOverride makes f draw r with a custom outline instead of the
+// font's glyph, so that logos or decorative initials flow inline with the
+// surrounding text. path is positioned relative to the glyph's origin on the
+// baseline, in pixels, and the pen moves on by advance after drawing it. The
+// override applies wherever f lays out r, including within a FontSet. A nil
+// path removes any override for r.
+func (f *Font) SetGlyphOverride(r rune, path *TextPath, advance float64) {
+	if path == nil {
+		delete(f.overrides, r)
+		return
+	}
+	if f.overrides == nil {
+		f.overrides = map[rune]glyphOverride{}
+	}
+	f.overrides[r] = glyphOverride{TextPath{PathOps: append([]Op(nil), path.PathOps...)}, advance}
+}
@@ -0,0 +1,174 @@
+package filmore
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// WritePDFContent writes p to w as PDF path construction operators followed
+// by f, which fills the path with the non-zero rule, for adding text as
+// outlines to the content stream of a PDF page. Quadratic curves are written
+// as the cubic curves that draw the same, since PDF has no others, and each
+// ClosePath as h. Coordinates are written as they are, with one unit per
+// pixel; since y points down in a TextPath and up in PDF, the content stream
+// has to flip the page, as WritePDF does.
+func WritePDFContent(w io.Writer, p *TextPath) error {
+	_, err := io.WriteString(w, pdfContent(p))
+	return err
+}
+
+// pdfContent returns the content stream operators that fill p.
+func pdfContent(p *TextPath) string {
+	if len(p.PathOps) == 0 {
+		return ""
+	}
+	return pdfPath(p) + "f\n"
+}
+
+// pdfPath returns the content stream operators that construct p, without
+// painting it.
+func pdfPath(p *TextPath) string {
+	cubic := TextPath{PathOps: append([]Op(nil), p.PathOps...)}
+	cubic.ToCubic()
+	var b strings.Builder
+	point := func(x, y float64) {
+		b.WriteString(svgNumber(x))
+		b.WriteByte(' ')
+		b.WriteString(svgNumber(y))
+		b.WriteByte(' ')
+	}
+	for _, op := range cubic.PathOps {
+		switch op := op.(type) {
+		case MoveTo:
+			point(op.x, op.y)
+			b.WriteString("m\n")
+		case LineTo:
+			point(op.x, op.y)
+			b.WriteString("l\n")
+		case ClosePath:
+			b.WriteString("h\n")
+		case CubicCurveTo:
+			point(op.cx1, op.cy1)
+			point(op.cx2, op.cy2)
+			point(op.x, op.y)
+			b.WriteString("c\n")
+		}
+	}
+	return b.String()
+}
+
+// WritePDF writes paths to w as a minimal one-page PDF document, filled in
+// black on a page width by height pixels, with one point per pixel, for
+// sending text as outlines straight to print. The paths are placed as they
+// are in TextPath coordinates, with the origin at the top left of the page.
+func WritePDF(w io.Writer, paths []TextPath, width, height float64) error {
+	var content strings.Builder
+	for i := range paths {
+		content.WriteString(pdfContent(&paths[i]))
+	}
+	return writePDFDocument(w, width, height, content.String())
+}
+
+// writePDFDocument writes a one-page PDF document to w, with a page width by
+// height points drawn by the content stream content, in which y points down
+// from the top left of the page, as in TextPath.
+func writePDFDocument(w io.Writer, width, height float64, content string) error {
+	// Flip the page so that y points down, as in TextPath.
+	content = fmt.Sprintf("1 0 0 -1 0 %s cm\n", svgNumber(height)) + content
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] /Contents 4 0 R >>", svgNumber(width), svgNumber(height)),
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", len(content), content),
+	}
+	var b strings.Builder
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	xref := b.Len()
+	// Each entry of the cross-reference table is exactly 20 bytes long.
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writePDFPath writes the content stream operators that paint p to b, as
+// SVG would with the presentation attributes in style: fill, which defaults
+// to black, fill-rule, stroke and stroke-width. Colors must be given as
+// #rgb, #rrggbb or one of a few basic names, such as black or white, or
+// none.
+func writePDFPath(b *strings.Builder, p *TextPath, style map[string]string) error {
+	fill, stroke := style["fill"], style["stroke"]
+	if fill == "" {
+		fill = "black"
+	}
+	var paint string
+	if fill != "none" {
+		rgb, err := pdfColor(fill)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(b, "%s rg\n", rgb)
+		paint = "f"
+	}
+	if stroke != "" && stroke != "none" {
+		rgb, err := pdfColor(stroke)
+		if err != nil {
+			return err
+		}
+		width := 1.0
+		if v := style["stroke-width"]; v != "" {
+			if width, err = strconv.ParseFloat(strings.TrimSuffix(v, "px"), 64); err != nil {
+				return fmt.Errorf("filmore: bad stroke-width %q", v)
+			}
+		}
+		fmt.Fprintf(b, "%s RG %s w\n", rgb, svgNumber(width))
+		if paint == "f" {
+			paint = "B"
+		} else {
+			paint = "S"
+		}
+	}
+	if paint == "" || len(p.PathOps) == 0 {
+		return nil
+	}
+	if paint != "S" && style["fill-rule"] == "evenodd" {
+		paint += "*"
+	}
+	b.WriteString(pdfPath(p))
+	b.WriteString(paint + "\n")
+	return nil
+}
+
+// pdfColors holds the color names pdfColor accepts.
+var pdfColors = map[string]string{
+	"black": "#000000", "white": "#ffffff", "red": "#ff0000", "green": "#008000",
+	"blue": "#0000ff", "gray": "#808080", "grey": "#808080",
+}
+
+// pdfColor returns the operands of the rg operator that select color, given
+// as for writePDFPath.
+func pdfColor(color string) (string, error) {
+	hex := strings.ToLower(strings.TrimSpace(color))
+	if named, ok := pdfColors[hex]; ok {
+		hex = named
+	}
+	if len(hex) == 4 && hex[0] == '#' {
+		hex = string([]byte{'#', hex[1], hex[1], hex[2], hex[2], hex[3], hex[3]})
+	}
+	v, err := strconv.ParseUint(strings.TrimPrefix(hex, "#"), 16, 32)
+	if len(hex) != 7 || hex[0] != '#' || err != nil {
+		return "", fmt.Errorf("filmore: color %q is not supported in PDF", color)
+	}
+	c := func(shift uint) string { return svgNumber(float64(v>>shift&0xff) / 255) }
+	return c(16) + " " + c(8) + " " + c(0), nil
+}
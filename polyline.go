@@ -0,0 +1,191 @@
+package filmore
+
+import (
+	"math"
+	"sort"
+)
+
+// A CornerPolicy says what CreatePolylinePath does with glyphs that fall on
+// the corners of the line.
+type CornerPolicy int
+
+const (
+	// CornerRotate turns each glyph as a whole to follow the line at its
+	// middle, so glyphs at corners may overlap or spread apart.
+	CornerRotate CornerPolicy = iota
+	// CornerSkip is like CornerRotate, but moves glyphs that would straddle a
+	// sharp corner along to start just past it, leaving a gap in the text.
+	CornerSkip
+	// CornerBend bends each glyph around the line, so that glyphs at corners
+	// fold around them. Bent glyphs contain only straight segments.
+	CornerBend
+)
+
+// PolylineOptions adjusts how CreatePolylinePath lays text along a line. The
+// zero value turns each glyph to follow the line and flips lines that run
+// leftward.
+type PolylineOptions struct {
+	Corner CornerPolicy
+	// SharpAngle is how far, in radians, the line must turn at a corner for
+	// CornerSkip to keep glyphs off it. It defaults to π/6.
+	SharpAngle float64
+	// Offset is how far along the line, in pixels, the text starts.
+	Offset float64
+	// NoFlip keeps the text running from the first point of the line to the
+	// last even if that is leftward, which draws it upside down.
+	NoFlip bool
+}
+
+// CreatePolylinePath lays s along the open polyline line, such as a road or
+// river on a map, with its baseline on the line and its glyphs above it. If
+// the line ends to the left of where it starts, it is followed from its last
+// point to its first instead, so that the text reads left to right and
+// upright, unless opts.NoFlip is set. Glyphs that run off the end of the line
+// continue straight on. opts may be nil. The result's Width is the width of
+// the text.
+func (f *Font) CreatePolylinePath(s string, line []Point, opts *PolylineOptions) TextPath {
+	if opts == nil {
+		opts = &PolylineOptions{}
+	}
+	if len(line) < 2 {
+		return TextPath{}
+	}
+	if !opts.NoFlip && line[len(line)-1].X < line[0].X {
+		reversed := make([]Point, len(line))
+		for i, pt := range line {
+			reversed[len(line)-1-i] = pt
+		}
+		line = reversed
+	}
+	run, err := f.CreateGlyphRun(s, 0, 0, nil)
+	if err != nil {
+		return TextPath{}
+	}
+	along := newPolylineMap(line)
+	sharp := opts.SharpAngle
+	if sharp <= 0 {
+		sharp = math.Pi / 6
+	}
+
+	var result TextPath
+	shift := opts.Offset
+	for _, g := range run {
+		result.Width += g.Advance
+		if opts.Corner == CornerBend {
+			result.appendBent(&g.Path, shift, along)
+			continue
+		}
+		if opts.Corner == CornerSkip {
+			for i, d := range along.dists {
+				if start := shift + g.X; d > start && d < start+g.Advance && along.turn(i) > sharp {
+					shift += d - start
+				}
+			}
+		}
+		mid := shift + g.X + g.Advance/2
+		px, py, tx, ty := along.at(mid)
+		p := g.Path.mapPoints(func(x, y float64) (float64, float64) {
+			u := x - g.X - g.Advance/2
+			return px + u*tx - y*ty, py + u*ty + y*tx
+		})
+		result.PathOps = append(result.PathOps, p.PathOps...)
+	}
+	return result
+}
+
+// appendBent appends the outline of glyph to p, bent so that the baseline of
+// its x coordinates, plus shift, runs along the line.
+func (p *TextPath) appendBent(glyph *TextPath, shift float64, along *polylineMap) {
+	bend := func(pt Point) Point {
+		px, py, tx, ty := along.at(pt.X + shift)
+		return Point{px - pt.Y*ty, py + pt.Y*tx}
+	}
+	for _, poly := range glyph.Flatten(defaultTolerance) {
+		first := bend(poly[0])
+		p.MoveTo(first.X, first.Y)
+		for i := 1; i < len(poly); i++ {
+			// Pass through the corners the segment crosses, so that it
+			// folds around them.
+			a, b := poly[i-1], poly[i]
+			da, db := a.X+shift, b.X+shift
+			for _, d := range along.between(da, db) {
+				t := (d - da) / (db - da)
+				q := bend(Point{a.X + t*(b.X-a.X), a.Y + t*(b.Y-a.Y)})
+				p.LineTo(q.X, q.Y)
+			}
+			q := bend(b)
+			p.LineTo(q.X, q.Y)
+		}
+	}
+}
+
+// A polylineMap finds points of a polyline by their distance along it.
+type polylineMap struct {
+	line []Point
+	// dists holds the distance along the line of each of its points.
+	dists []float64
+}
+
+func newPolylineMap(line []Point) *polylineMap {
+	m := &polylineMap{line: line, dists: make([]float64, len(line))}
+	for i := 1; i < len(line); i++ {
+		m.dists[i] = m.dists[i-1] + math.Hypot(line[i].X-line[i-1].X, line[i].Y-line[i-1].Y)
+	}
+	return m
+}
+
+// at returns the point at distance d along the line, and the unit direction
+// of the line there. Distances beyond the ends of the line continue the first
+// or last segment.
+func (m *polylineMap) at(d float64) (x, y, tx, ty float64) {
+	i := sort.SearchFloat64s(m.dists, d)
+	if i < 1 {
+		i = 1
+	}
+	if i > len(m.line)-1 {
+		i = len(m.line) - 1
+	}
+	// Skip back over points that coincide.
+	for i > 1 && m.dists[i] == m.dists[i-1] {
+		i--
+	}
+	a, b := m.line[i-1], m.line[i]
+	l := m.dists[i] - m.dists[i-1]
+	if l == 0 {
+		return a.X, a.Y, 1, 0
+	}
+	tx, ty = (b.X-a.X)/l, (b.Y-a.Y)/l
+	t := d - m.dists[i-1]
+	return a.X + t*tx, a.Y + t*ty, tx, ty
+}
+
+// turn returns the angle the line turns through at its point i, in radians,
+// or 0 at its ends.
+func (m *polylineMap) turn(i int) float64 {
+	if i <= 0 || i >= len(m.line)-1 {
+		return 0
+	}
+	a, b, c := m.line[i-1], m.line[i], m.line[i+1]
+	return math.Abs(math.Atan2(cross(b.X-a.X, b.Y-a.Y, c.X-b.X, c.Y-b.Y), (b.X-a.X)*(c.X-b.X)+(b.Y-a.Y)*(c.Y-b.Y)))
+}
+
+// between returns the distances of the inner points of the line that lie
+// strictly between d0 and d1, in order from d0 to d1.
+func (m *polylineMap) between(d0, d1 float64) []float64 {
+	var result []float64
+	inner := m.dists[1 : len(m.dists)-1]
+	if d0 < d1 {
+		for _, d := range inner {
+			if d > d0 && d < d1 {
+				result = append(result, d)
+			}
+		}
+	} else {
+		for i := len(inner) - 1; i >= 0; i-- {
+			if d := inner[i]; d > d1 && d < d0 {
+				result = append(result, d)
+			}
+		}
+	}
+	return result
+}
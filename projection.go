@@ -0,0 +1,85 @@
+package filmore
+
+import "math"
+
+// An IsometricPlane is a face of a cube drawn in isometric projection.
+type IsometricPlane int
+
+const (
+	// IsometricLeft is the vertical face on the left, whose text runs down
+	// to the right.
+	IsometricLeft IsometricPlane = iota
+	// IsometricRight is the vertical face on the right, whose text runs up
+	// to the right.
+	IsometricRight
+	// IsometricTop is the horizontal face on top.
+	IsometricTop
+)
+
+// Axonometric returns p projected as if drawn on a plane seen in an
+// axonometric view, taking x, y as the origin. The text's baseline direction
+// is drawn at xAngle and its upward direction at yAngle, both in radians
+// counterclockwise from the rightward horizontal, and distances along both
+// are kept. Axonometric(0, math.Pi/2, x, y) leaves p unchanged.
+func (p *TextPath) Axonometric(xAngle, yAngle, x, y float64) TextPath {
+	// The page's y axis points down, so angles measured upward have their
+	// sines negated, and p's downward direction is opposite its upward one.
+	ux, uy := math.Cos(xAngle), -math.Sin(xAngle)
+	vx, vy := -math.Cos(yAngle), math.Sin(yAngle)
+	result := p.mapPoints(func(px, py float64) (float64, float64) {
+		dx, dy := px-x, py-y
+		return x + dx*ux + dy*vx, y + dx*uy + dy*vy
+	})
+	if cross(ux, uy, vx, vy) < 0 {
+		// The projection is a reflection; restore the glyphs' winding.
+		result.Reverse()
+	}
+	return result
+}
+
+// Isometric returns p laid onto a face of an isometric drawing, taking x, y
+// as the origin: the corner of the face where the text starts. The axes of
+// isometric drawings are 30 degrees from the horizontal, and distances along
+// them are not foreshortened.
+func (p *TextPath) Isometric(plane IsometricPlane, x, y float64) TextPath {
+	const deg30 = math.Pi / 6
+	switch plane {
+	case IsometricLeft:
+		return p.Axonometric(-deg30, math.Pi/2, x, y)
+	case IsometricRight:
+		return p.Axonometric(deg30, math.Pi/2, x, y)
+	}
+	return p.Axonometric(deg30, math.Pi-deg30, x, y)
+}
+
+// LongShadow returns the area p sweeps over when moved length pixels in the
+// direction angle, in radians counterclockwise from the rightward
+// horizontal. Filled beneath p, it gives the long shadow of flat design,
+// joined to the letters without gaps. The result includes p's own area and,
+// like the results of Combine, contains only straight segments.
+func (p *TextPath) LongShadow(angle, length float64) TextPath {
+	dx, dy := length*math.Cos(angle), -length*math.Sin(angle)
+	// The sweep is p together with the parallelogram each of its edges
+	// sweeps over.
+	var sweep TextPath
+	for _, poly := range p.Flatten(defaultTolerance) {
+		for i := 0; i+1 < len(poly); i++ {
+			a, b := poly[i], poly[i+1]
+			c := cross(b.X-a.X, b.Y-a.Y, dx, dy)
+			if math.Abs(c) < 1e-9 {
+				continue
+			}
+			// Wind each parallelogram like an outer contour, so that they
+			// add up rather than cancel where they overlap.
+			if c < 0 {
+				a, b = b, a
+			}
+			sweep.MoveTo(a.X, a.Y)
+			sweep.LineTo(b.X, b.Y)
+			sweep.LineTo(b.X+dx, b.Y+dy)
+			sweep.LineTo(a.X+dx, a.Y+dy)
+			sweep.LineTo(a.X, a.Y)
+		}
+	}
+	return p.Combine(BooleanUnion, &sweep)
+}
@@ -0,0 +1,224 @@
+package filmore
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+// Kinds of op in the Op message of rpc/filmore.proto.
+const (
+	protoMoveTo = iota
+	protoLineTo
+	protoQuadTo
+	protoClosePath
+	protoCubeTo
+)
+
+// Protocol buffer wire types.
+const (
+	protoVarint  = 0
+	protoFixed64 = 1
+	protoBytes   = 2
+	protoFixed32 = 5
+)
+
+// MarshalProto returns p encoded as the TextPath message of
+// rpc/filmore.proto, for exchanging paths with services in other languages
+// through their own protocol buffer code.
+func (p *TextPath) MarshalProto() []byte {
+	var buf []byte
+	for _, op := range p.PathOps {
+		kind, v := uint64(protoLineTo), []float64{op.X(), op.Y()}
+		switch op := op.(type) {
+		case MoveTo:
+			kind = protoMoveTo
+		case ClosePath:
+			kind = protoClosePath
+		case QuadCurveTo:
+			kind, v = protoQuadTo, append(v, op.cx, op.cy)
+		case CubicCurveTo:
+			kind, v = protoCubeTo, append(v, op.cx1, op.cy1, op.cx2, op.cy2)
+		}
+		m := appendProtoVarint(nil, 1, kind)
+		for i, c := range v {
+			m = appendProtoDouble(m, 2+i, c)
+		}
+		buf = appendProtoBytes(buf, 1, m)
+	}
+	buf = appendProtoDouble(buf, 2, p.Width)
+	buf = appendProtoDouble(buf, 3, p.Height)
+	for _, s := range p.Sources {
+		// Negative int32s are sign-extended to 64 bits.
+		m := appendProtoVarint(nil, 1, uint64(int64(s.RuneIndex)))
+		m = appendProtoVarint(m, 2, uint64(s.Glyph))
+		m = appendProtoVarint(m, 3, uint64(int64(s.Start)))
+		m = appendProtoVarint(m, 4, uint64(int64(s.End)))
+		buf = appendProtoBytes(buf, 4, m)
+	}
+	return buf
+}
+
+// UnmarshalProto sets p to the path in data, an encoded TextPath message of
+// rpc/filmore.proto. Fields it doesn't know are skipped, as protocol
+// buffers require, so that later versions of the schema can add to it.
+func (p *TextPath) UnmarshalProto(data []byte) error {
+	var path TextPath
+	err := readProto(data, func(num, wire int, v uint64, b []byte) error {
+		switch {
+		case num == 1 && wire == protoBytes:
+			return path.appendProtoOp(b)
+		case num == 2 && wire == protoFixed64:
+			path.Width = math.Float64frombits(v)
+		case num == 3 && wire == protoFixed64:
+			path.Height = math.Float64frombits(v)
+		case num == 4 && wire == protoBytes:
+			var s [4]int
+			err := readProto(b, func(num, wire int, v uint64, _ []byte) error {
+				if num < 1 || num > 4 {
+					return nil
+				}
+				if wire != protoVarint {
+					return fmt.Errorf("source field %d has wire type %d", num, wire)
+				}
+				s[num-1] = int(int32(v))
+				return nil
+			})
+			if err != nil {
+				return err
+			}
+			path.Sources = append(path.Sources, GlyphSource{s[0], truetype.Index(uint32(s[1])), s[2], s[3]})
+		case num >= 1 && num <= 4:
+			return fmt.Errorf("field %d has wire type %d", num, wire)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("filmore: reading protobuf path: %v", err)
+	}
+	for _, s := range path.Sources {
+		if s.Start < 0 || s.Start > s.End || s.End > len(path.PathOps) {
+			return fmt.Errorf("filmore: reading protobuf path: source out of range")
+		}
+	}
+	*p = path
+	return nil
+}
+
+// appendProtoOp appends the op encoded in the Op message m to p.
+func (p *TextPath) appendProtoOp(m []byte) error {
+	var kind uint64
+	var v [6]float64
+	err := readProto(m, func(num, wire int, c uint64, _ []byte) error {
+		switch {
+		case num == 1 && wire == protoVarint:
+			kind = c
+		case num >= 2 && num <= 7 && wire == protoFixed64:
+			v[num-2] = math.Float64frombits(c)
+		case num >= 1 && num <= 7:
+			return fmt.Errorf("op field %d has wire type %d", num, wire)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	switch kind {
+	case protoMoveTo:
+		p.MoveTo(v[0], v[1])
+	case protoLineTo:
+		p.LineTo(v[0], v[1])
+	case protoQuadTo:
+		p.QuadCurveTo(v[0], v[1], v[2], v[3])
+	case protoClosePath:
+		p.ClosePath()
+	case protoCubeTo:
+		p.CubicCurveTo(v[0], v[1], v[2], v[3], v[4], v[5])
+	default:
+		return fmt.Errorf("unknown op kind %d", kind)
+	}
+	return nil
+}
+
+// appendProtoVarint appends field num with the varint value v to buf,
+// leaving it out if v is zero, as proto3 does.
+func appendProtoVarint(buf []byte, num int, v uint64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = appendUvarint(buf, uint64(num)<<3|protoVarint)
+	return appendUvarint(buf, v)
+}
+
+// appendProtoDouble appends field num with the double value v to buf,
+// leaving it out if v is zero, as proto3 does.
+func appendProtoDouble(buf []byte, num int, v float64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = appendUvarint(buf, uint64(num)<<3|protoFixed64)
+	var word [8]byte
+	binary.LittleEndian.PutUint64(word[:], math.Float64bits(v))
+	return append(buf, word[:]...)
+}
+
+// appendProtoBytes appends field num with the length-delimited value b,
+// such as an encoded message, to buf.
+func appendProtoBytes(buf []byte, num int, b []byte) []byte {
+	buf = appendUvarint(buf, uint64(num)<<3|protoBytes)
+	buf = appendUvarint(buf, uint64(len(b)))
+	return append(buf, b...)
+}
+
+// appendUvarint appends v to buf as a varint.
+func appendUvarint(buf []byte, v uint64) []byte {
+	var b [binary.MaxVarintLen64]byte
+	return append(buf, b[:binary.PutUvarint(b[:], v)]...)
+}
+
+// readProto calls field for each field of the encoded message data in turn,
+// with its number and wire type, and its value in v, or in b if it is
+// length-delimited.
+func readProto(data []byte, field func(num, wire int, v uint64, b []byte) error) error {
+	for len(data) > 0 {
+		key, n := binary.Uvarint(data)
+		if n <= 0 || key>>3 == 0 || key>>3 > math.MaxInt32 {
+			return fmt.Errorf("bad field key")
+		}
+		data = data[n:]
+		num, wire := int(key>>3), int(key&7)
+		var v uint64
+		var b []byte
+		switch wire {
+		case protoVarint:
+			if v, n = binary.Uvarint(data); n <= 0 {
+				return fmt.Errorf("field %d is truncated", num)
+			}
+			data = data[n:]
+		case protoFixed64:
+			if len(data) < 8 {
+				return fmt.Errorf("field %d is truncated", num)
+			}
+			v, data = binary.LittleEndian.Uint64(data), data[8:]
+		case protoFixed32:
+			if len(data) < 4 {
+				return fmt.Errorf("field %d is truncated", num)
+			}
+			v, data = uint64(binary.LittleEndian.Uint32(data)), data[4:]
+		case protoBytes:
+			l, n := binary.Uvarint(data)
+			if n <= 0 || l > uint64(len(data)-n) {
+				return fmt.Errorf("field %d is truncated", num)
+			}
+			b, data = data[n:n+int(l)], data[n+int(l):]
+		default:
+			return fmt.Errorf("field %d has unsupported wire type %d", num, wire)
+		}
+		if err := field(num, wire, v, b); err != nil {
+			return err
+		}
+	}
+	return nil
+}
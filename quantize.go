@@ -0,0 +1,21 @@
+package filmore
+
+import "math"
+
+// Quantize rounds every end and control point of p to the nearest multiple
+// of grid, in place, so that exported SVG and JSON are compact and come out
+// the same on every platform. A grid of 0.01 keeps two decimal places; use
+// math.Pow10(-n) for n places. A grid of zero or less leaves p alone.
+func (p *TextPath) Quantize(grid float64) {
+	if grid <= 0 {
+		return
+	}
+	round := func(v float64) float64 { return math.Round(v/grid) * grid }
+	// Dividing by a whole number of steps per pixel gives the closest
+	// float64 to a decimal such as 0.3, which prints short, where
+	// multiplying by 0.1 would give 0.30000000000000004.
+	if n := math.Round(1 / grid); grid < 1 && math.Abs(n-1/grid) < 1e-9*n {
+		round = func(v float64) float64 { return math.Round(v*n) / n }
+	}
+	*p = p.mapPoints(func(x, y float64) (float64, float64) { return round(x), round(y) })
+}
@@ -0,0 +1,50 @@
+package filmore
+
+import "math"
+
+// A RingOrientation says how CreateRingPath turns the labels around a ring.
+type RingOrientation int
+
+const (
+	// RingUpright keeps every label upright, like the numerals of most clock
+	// faces.
+	RingUpright RingOrientation = iota
+	// RingOutward turns each label so that its top points away from the
+	// center, to be read from outside the ring.
+	RingOutward
+	// RingInward turns each label so that its top points toward the center,
+	// to be read from inside the ring, as on a bezel.
+	RingInward
+)
+
+// CreateRingPath places labels evenly around a circle of the given radius
+// about cx, cy, for watch faces, dials and gauges. The first label goes at
+// startAngle, in radians clockwise from the top of the circle, and the rest
+// follow clockwise, so that twelve labels starting at 0 go where a clock's
+// numerals do. Each label is centered on its point of the circle, on its
+// middle and half its capital height.
+func (f *Font) CreateRingPath(labels []string, cx, cy, radius, startAngle float64, orient RingOrientation) TextPath {
+	var result TextPath
+	mid := f.CapHeight() / 2
+	for i, label := range labels {
+		angle := startAngle + 2*math.Pi*float64(i)/float64(len(labels))
+		px, py := cx+radius*math.Sin(angle), cy-radius*math.Cos(angle)
+		turn := 0.0
+		switch orient {
+		case RingOutward:
+			turn = angle
+		case RingInward:
+			turn = angle + math.Pi
+		}
+		// With y pointing down, this turns the label clockwise by turn.
+		sin, cos := math.Sin(turn), math.Cos(turn)
+		p := f.CreateTextPath(label, 0, 0)
+		w := p.Width
+		p = p.mapPoints(func(x, y float64) (float64, float64) {
+			x, y = x-w/2, y+mid
+			return px + x*cos - y*sin, py + x*sin + y*cos
+		})
+		result.PathOps = append(result.PathOps, p.PathOps...)
+	}
+	return result
+}
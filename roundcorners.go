@@ -0,0 +1,128 @@
+package filmore
+
+import "math"
+
+// RoundCorners returns p with its sharp corners rounded off to about radius
+// pixels, for soft lettering such as on signs and stickers. Each corner is
+// replaced by a curve that leaves the segments on either side smoothly; where
+// those segments are too short for the full radius, the curve is made as big
+// as half the shorter of them allows. Corners where the outline already turns
+// smoothly are left alone. Sources are carried over.
+func (p *TextPath) RoundCorners(radius float64) TextPath {
+	result := TextPath{Width: p.Width, Height: p.Height}
+	// starts maps the index of each op that starts a contour to its index in
+	// result.
+	starts := make([]int, len(p.PathOps)+1)
+	for i := 0; ; i++ {
+		start, end, ok := p.subPath(i)
+		if !ok {
+			break
+		}
+		starts[start] = len(result.PathOps)
+		result.appendRoundedContour(p.PathOps[start:end], radius)
+	}
+	starts[len(p.PathOps)] = len(result.PathOps)
+	for _, src := range p.Sources {
+		src.Start, src.End = starts[src.Start], starts[src.End]
+		result.Sources = append(result.Sources, src)
+	}
+	return result
+}
+
+// A roundedSegment is a segment of a contour being rounded by RoundCorners.
+type roundedSegment struct {
+	x0, y0 float64
+	op     Op
+	length float64
+	// trim0 and trim1 are how much of the segment's length is cut off its
+	// start and end to make room for the rounded corners there.
+	trim0, trim1 float64
+}
+
+// appendRoundedContour appends the contour drawn by ops with its corners
+// rounded to radius.
+func (p *TextPath) appendRoundedContour(ops []Op, radius float64) {
+	var x0, y0 float64
+	if _, ok := ops[0].(MoveTo); ok {
+		x0, y0 = ops[0].X(), ops[0].Y()
+		ops = ops[1:]
+	}
+	var segs []roundedSegment
+	for _, op := range ops {
+		if l := segmentLength(x0, y0, op); l > 1e-9 {
+			segs = append(segs, roundedSegment{x0: x0, y0: y0, op: op, length: l})
+		}
+		x0, y0 = op.X(), op.Y()
+	}
+	if len(segs) == 0 || radius <= 0 {
+		if len(segs) > 0 {
+			p.MoveTo(segs[0].x0, segs[0].y0)
+		}
+		for _, s := range segs {
+			p.PathOps = append(p.PathOps, s.op)
+		}
+		return
+	}
+	n := len(segs)
+	last := segs[n-1].op
+	closed := math.Hypot(last.X()-segs[0].x0, last.Y()-segs[0].y0) < 1e-9
+	// corners holds the point of the corner at the end of each segment, if
+	// it is rounded.
+	corners := make([]*Point, n)
+	for k := range segs {
+		next := k + 1
+		if next == n {
+			if !closed {
+				break
+			}
+			next = 0
+		}
+		a, b := &segs[k], &segs[next]
+		d1 := segmentDirection(a.x0, a.y0, a.op, 1)
+		d2 := segmentDirection(b.x0, b.y0, b.op, 0)
+		turn := math.Atan2(math.Abs(cross(d1.X, d1.Y, d2.X, d2.Y)), d1.X*d2.X+d1.Y*d2.Y)
+		if turn < 1e-3 {
+			continue
+		}
+		// A circle of radius r touching both segments meets them r tan(θ/2)
+		// from the corner, for a turn through θ.
+		d := math.Min(radius*math.Tan(turn/2), math.Min(a.length, b.length)/2)
+		a.trim1, b.trim0 = d, d
+		corners[k] = &Point{a.op.X(), a.op.Y()}
+	}
+	first := segs[0]
+	sx, sy := curvePoint(first.x0, first.y0, first.op, segmentParameter(first.x0, first.y0, first.op, first.trim0, first.length))
+	p.MoveTo(sx, sy)
+	for k, s := range segs {
+		if s.trim0+s.trim1 < s.length {
+			t0 := segmentParameter(s.x0, s.y0, s.op, s.trim0, s.length)
+			t1 := segmentParameter(s.x0, s.y0, s.op, s.length-s.trim1, s.length)
+			p.PathOps = append(p.PathOps, subSegment(s.x0, s.y0, s.op, t0, t1))
+		}
+		if corners[k] == nil {
+			continue
+		}
+		next := segs[(k+1)%n]
+		x, y := curvePoint(next.x0, next.y0, next.op, segmentParameter(next.x0, next.y0, next.op, next.trim0, next.length))
+		p.QuadCurveTo(x, y, corners[k].X, corners[k].Y)
+	}
+}
+
+// segmentDirection returns the unit tangent, in the direction of travel, of
+// the segment op draws from x0, y0 at parameter t, which is 0 or 1. Where the
+// derivative vanishes, as at a control point that coincides with an end
+// point, the direction is taken from a point close by.
+func segmentDirection(x0, y0 float64, op Op, t float64) Point {
+	dx, dy := curveDerivative(x0, y0, op, t)
+	if math.Hypot(dx, dy) < 1e-9 {
+		const h = 1e-3
+		ax, ay := curvePoint(x0, y0, op, math.Max(0, t-h))
+		bx, by := curvePoint(x0, y0, op, math.Min(1, t+h))
+		dx, dy = bx-ax, by-ay
+	}
+	l := math.Hypot(dx, dy)
+	if l == 0 {
+		return Point{}
+	}
+	return Point{dx / l, dy / l}
+}
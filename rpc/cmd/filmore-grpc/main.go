@@ -0,0 +1,128 @@
+// Command filmore-grpc is a reference server for the gRPC service defined in
+// rpc/filmore.proto, for using filmore from other languages.
+//
+// Usage:
+//
+//	filmore-grpc [-addr host:port]
+//
+// Each request loads its font afresh, from the font data it carries or from
+// the fonts installed on the server, so the server keeps no state between
+// requests.
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"net"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/jdf/filmore"
+	"github.com/jdf/filmore/rpc"
+)
+
+func main() {
+	addr := flag.String("addr", "localhost:50051", "the `address` to listen on")
+	flag.Parse()
+	log.SetFlags(0)
+	log.SetPrefix("filmore-grpc: ")
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+	s := grpc.NewServer()
+	rpc.RegisterFilmoreServer(s, server{})
+	log.Printf("listening on %s", lis.Addr())
+	log.Fatal(s.Serve(lis))
+}
+
+// server implements the Filmore service with the filmore package.
+type server struct {
+	rpc.UnimplementedFilmoreServer
+}
+
+// loadFont loads the font that f selects.
+func loadFont(f *rpc.Font) (*filmore.Font, error) {
+	if f.GetSize() <= 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "font size %d is not positive", f.GetSize())
+	}
+	switch src := f.GetSource().(type) {
+	case *rpc.Font_Data:
+		font, err := filmore.NewFont(src.Data, int(f.GetSize()))
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+		return font, nil
+	case *rpc.Font_Family:
+		path, err := filmore.FindFont(src.Family, filmore.Style(f.GetStyle()))
+		if err != nil {
+			return nil, status.Error(codes.NotFound, err.Error())
+		}
+		font, err := filmore.NewFontFromFile(path, int(f.GetSize()))
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		return font, nil
+	}
+	return nil, status.Error(codes.InvalidArgument, "no font given")
+}
+
+func (server) RenderText(ctx context.Context, req *rpc.RenderTextRequest) (*rpc.RenderTextResponse, error) {
+	f, err := loadFont(req.GetFont())
+	if err != nil {
+		return nil, err
+	}
+	p, err := f.CreateTextPathOptions(req.GetText(), req.GetX(), req.GetY(), &filmore.TextOptions{
+		RightToLeft: req.GetRightToLeft(),
+		Bidi:        req.GetBidi(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	// MarshalProto encodes the path as a TextPath message, whose ops are
+	// those of the response.
+	var path rpc.TextPath
+	if err := proto.Unmarshal(p.MarshalProto(), &path); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &rpc.RenderTextResponse{Ops: path.Ops, Width: path.Width}, nil
+}
+
+func (server) MeasureText(ctx context.Context, req *rpc.MeasureTextRequest) (*rpc.MeasureTextResponse, error) {
+	f, err := loadFont(req.GetFont())
+	if err != nil {
+		return nil, err
+	}
+	width, ascent, descent := f.Measure(req.GetText())
+	return &rpc.MeasureTextResponse{
+		Width:    width,
+		Ascent:   ascent,
+		Descent:  descent,
+		Advances: f.Advances(req.GetText()),
+	}, nil
+}
+
+func (server) FontInfo(ctx context.Context, req *rpc.FontInfoRequest) (*rpc.FontInfoResponse, error) {
+	f, err := loadFont(req.GetFont())
+	if err != nil {
+		return nil, err
+	}
+	return &rpc.FontInfoResponse{
+		Family:    f.Family(),
+		Style:     f.Style(),
+		FullName:  f.FullName(),
+		Version:   f.Version(),
+		License:   f.License(),
+		Ascent:    f.Ascent(),
+		Descent:   f.Descent(),
+		LineGap:   f.LineGap(),
+		CapHeight: f.CapHeight(),
+		XHeight:   f.XHeight(),
+		RuneCount: int32(len(f.Runes())),
+	}, nil
+}
@@ -0,0 +1,7 @@
+// Package rpc holds the gRPC service for using filmore from other languages,
+// generated from filmore.proto, and in cmd/filmore-grpc a reference server
+// for it. It is a module of its own, so that the filmore module itself
+// doesn't depend on gRPC.
+package rpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative filmore.proto
@@ -0,0 +1,1250 @@
+// Service definition for using filmore from other languages. A server
+// implements it by loading the requested font with filmore.NewFont, or
+// filmore.FindFont for a family name, and calling the library functions
+// named in each method's comment, as the reference server in
+// cmd/filmore-grpc does.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.34.2
+// 	protoc        (unknown)
+// source: filmore.proto
+
+package rpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Style int32
+
+const (
+	Style_REGULAR     Style = 0
+	Style_BOLD        Style = 1
+	Style_ITALIC      Style = 2
+	Style_BOLD_ITALIC Style = 3
+)
+
+// Enum value maps for Style.
+var (
+	Style_name = map[int32]string{
+		0: "REGULAR",
+		1: "BOLD",
+		2: "ITALIC",
+		3: "BOLD_ITALIC",
+	}
+	Style_value = map[string]int32{
+		"REGULAR":     0,
+		"BOLD":        1,
+		"ITALIC":      2,
+		"BOLD_ITALIC": 3,
+	}
+)
+
+func (x Style) Enum() *Style {
+	p := new(Style)
+	*p = x
+	return p
+}
+
+func (x Style) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Style) Descriptor() protoreflect.EnumDescriptor {
+	return file_filmore_proto_enumTypes[0].Descriptor()
+}
+
+func (Style) Type() protoreflect.EnumType {
+	return &file_filmore_proto_enumTypes[0]
+}
+
+func (x Style) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Style.Descriptor instead.
+func (Style) EnumDescriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{0}
+}
+
+type Op_Kind int32
+
+const (
+	Op_MOVE_TO       Op_Kind = 0
+	Op_LINE_TO       Op_Kind = 1
+	Op_QUAD_CURVE_TO Op_Kind = 2
+	// CLOSE_PATH draws a line back to the start of the contour, which x and
+	// y give.
+	Op_CLOSE_PATH     Op_Kind = 3
+	Op_CUBIC_CURVE_TO Op_Kind = 4
+)
+
+// Enum value maps for Op_Kind.
+var (
+	Op_Kind_name = map[int32]string{
+		0: "MOVE_TO",
+		1: "LINE_TO",
+		2: "QUAD_CURVE_TO",
+		3: "CLOSE_PATH",
+		4: "CUBIC_CURVE_TO",
+	}
+	Op_Kind_value = map[string]int32{
+		"MOVE_TO":        0,
+		"LINE_TO":        1,
+		"QUAD_CURVE_TO":  2,
+		"CLOSE_PATH":     3,
+		"CUBIC_CURVE_TO": 4,
+	}
+)
+
+func (x Op_Kind) Enum() *Op_Kind {
+	p := new(Op_Kind)
+	*p = x
+	return p
+}
+
+func (x Op_Kind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Op_Kind) Descriptor() protoreflect.EnumDescriptor {
+	return file_filmore_proto_enumTypes[1].Descriptor()
+}
+
+func (Op_Kind) Type() protoreflect.EnumType {
+	return &file_filmore_proto_enumTypes[1]
+}
+
+func (x Op_Kind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Op_Kind.Descriptor instead.
+func (Op_Kind) EnumDescriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{1, 0}
+}
+
+// Font selects a font and the size to set it at.
+type Font struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// Types that are assignable to Source:
+	//	*Font_Data
+	//	*Font_Family
+	Source isFont_Source `protobuf_oneof:"source"`
+	// The face to use when selecting by family.
+	Style Style `protobuf:"varint,3,opt,name=style,proto3,enum=filmore.Style" json:"style,omitempty"`
+	// The size in points.
+	Size int32 `protobuf:"varint,4,opt,name=size,proto3" json:"size,omitempty"`
+}
+
+func (x *Font) Reset() {
+	*x = Font{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Font) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Font) ProtoMessage() {}
+
+func (x *Font) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Font.ProtoReflect.Descriptor instead.
+func (*Font) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{0}
+}
+
+func (m *Font) GetSource() isFont_Source {
+	if m != nil {
+		return m.Source
+	}
+	return nil
+}
+
+func (x *Font) GetData() []byte {
+	if x, ok := x.GetSource().(*Font_Data); ok {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Font) GetFamily() string {
+	if x, ok := x.GetSource().(*Font_Family); ok {
+		return x.Family
+	}
+	return ""
+}
+
+func (x *Font) GetStyle() Style {
+	if x != nil {
+		return x.Style
+	}
+	return Style_REGULAR
+}
+
+func (x *Font) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+type isFont_Source interface {
+	isFont_Source()
+}
+
+type Font_Data struct {
+	// The contents of a TrueType font file.
+	Data []byte `protobuf:"bytes,1,opt,name=data,proto3,oneof"`
+}
+
+type Font_Family struct {
+	// The family name of a font installed on the server.
+	Family string `protobuf:"bytes,2,opt,name=family,proto3,oneof"`
+}
+
+func (*Font_Data) isFont_Source() {}
+
+func (*Font_Family) isFont_Source() {}
+
+// Op is one op of a path. Coordinates are in pixels with y pointing down.
+type Op struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind Op_Kind `protobuf:"varint,1,opt,name=kind,proto3,enum=filmore.Op_Kind" json:"kind,omitempty"`
+	X    float64 `protobuf:"fixed64,2,opt,name=x,proto3" json:"x,omitempty"`
+	Y    float64 `protobuf:"fixed64,3,opt,name=y,proto3" json:"y,omitempty"`
+	// The control point, for QUAD_CURVE_TO, or the first control point, for
+	// CUBIC_CURVE_TO.
+	ControlX float64 `protobuf:"fixed64,4,opt,name=control_x,json=controlX,proto3" json:"control_x,omitempty"`
+	ControlY float64 `protobuf:"fixed64,5,opt,name=control_y,json=controlY,proto3" json:"control_y,omitempty"`
+	// The second control point, for CUBIC_CURVE_TO.
+	Control2X float64 `protobuf:"fixed64,6,opt,name=control2_x,json=control2X,proto3" json:"control2_x,omitempty"`
+	Control2Y float64 `protobuf:"fixed64,7,opt,name=control2_y,json=control2Y,proto3" json:"control2_y,omitempty"`
+}
+
+func (x *Op) Reset() {
+	*x = Op{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Op) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Op) ProtoMessage() {}
+
+func (x *Op) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Op.ProtoReflect.Descriptor instead.
+func (*Op) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *Op) GetKind() Op_Kind {
+	if x != nil {
+		return x.Kind
+	}
+	return Op_MOVE_TO
+}
+
+func (x *Op) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *Op) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *Op) GetControlX() float64 {
+	if x != nil {
+		return x.ControlX
+	}
+	return 0
+}
+
+func (x *Op) GetControlY() float64 {
+	if x != nil {
+		return x.ControlY
+	}
+	return 0
+}
+
+func (x *Op) GetControl2X() float64 {
+	if x != nil {
+		return x.Control2X
+	}
+	return 0
+}
+
+func (x *Op) GetControl2Y() float64 {
+	if x != nil {
+		return x.Control2Y
+	}
+	return 0
+}
+
+// TextPath is a path with its metrics, as filmore.TextPath, which
+// TextPath.MarshalProto and TextPath.UnmarshalProto convert to and from this
+// message's encoding.
+type TextPath struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ops     []*Op          `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+	Width   float64        `protobuf:"fixed64,2,opt,name=width,proto3" json:"width,omitempty"`
+	Height  float64        `protobuf:"fixed64,3,opt,name=height,proto3" json:"height,omitempty"`
+	Sources []*GlyphSource `protobuf:"bytes,4,rep,name=sources,proto3" json:"sources,omitempty"`
+}
+
+func (x *TextPath) Reset() {
+	*x = TextPath{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *TextPath) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TextPath) ProtoMessage() {}
+
+func (x *TextPath) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TextPath.ProtoReflect.Descriptor instead.
+func (*TextPath) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *TextPath) GetOps() []*Op {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+func (x *TextPath) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *TextPath) GetHeight() float64 {
+	if x != nil {
+		return x.Height
+	}
+	return 0
+}
+
+func (x *TextPath) GetSources() []*GlyphSource {
+	if x != nil {
+		return x.Sources
+	}
+	return nil
+}
+
+// GlyphSource attributes the ops[start:end] of a TextPath to the glyph and
+// rune that produced them.
+type GlyphSource struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	RuneIndex int32  `protobuf:"varint,1,opt,name=rune_index,json=runeIndex,proto3" json:"rune_index,omitempty"`
+	Glyph     uint32 `protobuf:"varint,2,opt,name=glyph,proto3" json:"glyph,omitempty"`
+	Start     int32  `protobuf:"varint,3,opt,name=start,proto3" json:"start,omitempty"`
+	End       int32  `protobuf:"varint,4,opt,name=end,proto3" json:"end,omitempty"`
+}
+
+func (x *GlyphSource) Reset() {
+	*x = GlyphSource{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *GlyphSource) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GlyphSource) ProtoMessage() {}
+
+func (x *GlyphSource) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GlyphSource.ProtoReflect.Descriptor instead.
+func (*GlyphSource) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *GlyphSource) GetRuneIndex() int32 {
+	if x != nil {
+		return x.RuneIndex
+	}
+	return 0
+}
+
+func (x *GlyphSource) GetGlyph() uint32 {
+	if x != nil {
+		return x.Glyph
+	}
+	return 0
+}
+
+func (x *GlyphSource) GetStart() int32 {
+	if x != nil {
+		return x.Start
+	}
+	return 0
+}
+
+func (x *GlyphSource) GetEnd() int32 {
+	if x != nil {
+		return x.End
+	}
+	return 0
+}
+
+type RenderTextRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Font *Font  `protobuf:"bytes,1,opt,name=font,proto3" json:"font,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+	// Where the text starts on the baseline.
+	X float64 `protobuf:"fixed64,3,opt,name=x,proto3" json:"x,omitempty"`
+	Y float64 `protobuf:"fixed64,4,opt,name=y,proto3" json:"y,omitempty"`
+	// As in TextOptions.
+	RightToLeft bool `protobuf:"varint,5,opt,name=right_to_left,json=rightToLeft,proto3" json:"right_to_left,omitempty"`
+	Bidi        bool `protobuf:"varint,6,opt,name=bidi,proto3" json:"bidi,omitempty"`
+}
+
+func (x *RenderTextRequest) Reset() {
+	*x = RenderTextRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenderTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderTextRequest) ProtoMessage() {}
+
+func (x *RenderTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderTextRequest.ProtoReflect.Descriptor instead.
+func (*RenderTextRequest) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *RenderTextRequest) GetFont() *Font {
+	if x != nil {
+		return x.Font
+	}
+	return nil
+}
+
+func (x *RenderTextRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *RenderTextRequest) GetX() float64 {
+	if x != nil {
+		return x.X
+	}
+	return 0
+}
+
+func (x *RenderTextRequest) GetY() float64 {
+	if x != nil {
+		return x.Y
+	}
+	return 0
+}
+
+func (x *RenderTextRequest) GetRightToLeft() bool {
+	if x != nil {
+		return x.RightToLeft
+	}
+	return false
+}
+
+func (x *RenderTextRequest) GetBidi() bool {
+	if x != nil {
+		return x.Bidi
+	}
+	return false
+}
+
+type RenderTextResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ops   []*Op   `protobuf:"bytes,1,rep,name=ops,proto3" json:"ops,omitempty"`
+	Width float64 `protobuf:"fixed64,2,opt,name=width,proto3" json:"width,omitempty"`
+}
+
+func (x *RenderTextResponse) Reset() {
+	*x = RenderTextResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RenderTextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenderTextResponse) ProtoMessage() {}
+
+func (x *RenderTextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenderTextResponse.ProtoReflect.Descriptor instead.
+func (*RenderTextResponse) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *RenderTextResponse) GetOps() []*Op {
+	if x != nil {
+		return x.Ops
+	}
+	return nil
+}
+
+func (x *RenderTextResponse) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+type MeasureTextRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Font *Font  `protobuf:"bytes,1,opt,name=font,proto3" json:"font,omitempty"`
+	Text string `protobuf:"bytes,2,opt,name=text,proto3" json:"text,omitempty"`
+}
+
+func (x *MeasureTextRequest) Reset() {
+	*x = MeasureTextRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MeasureTextRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeasureTextRequest) ProtoMessage() {}
+
+func (x *MeasureTextRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeasureTextRequest.ProtoReflect.Descriptor instead.
+func (*MeasureTextRequest) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *MeasureTextRequest) GetFont() *Font {
+	if x != nil {
+		return x.Font
+	}
+	return nil
+}
+
+func (x *MeasureTextRequest) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type MeasureTextResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Width   float64 `protobuf:"fixed64,1,opt,name=width,proto3" json:"width,omitempty"`
+	Ascent  float64 `protobuf:"fixed64,2,opt,name=ascent,proto3" json:"ascent,omitempty"`
+	Descent float64 `protobuf:"fixed64,3,opt,name=descent,proto3" json:"descent,omitempty"`
+	// The advance of each rune of the text, as from Font.Advances.
+	Advances []float64 `protobuf:"fixed64,4,rep,packed,name=advances,proto3" json:"advances,omitempty"`
+}
+
+func (x *MeasureTextResponse) Reset() {
+	*x = MeasureTextResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MeasureTextResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeasureTextResponse) ProtoMessage() {}
+
+func (x *MeasureTextResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeasureTextResponse.ProtoReflect.Descriptor instead.
+func (*MeasureTextResponse) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *MeasureTextResponse) GetWidth() float64 {
+	if x != nil {
+		return x.Width
+	}
+	return 0
+}
+
+func (x *MeasureTextResponse) GetAscent() float64 {
+	if x != nil {
+		return x.Ascent
+	}
+	return 0
+}
+
+func (x *MeasureTextResponse) GetDescent() float64 {
+	if x != nil {
+		return x.Descent
+	}
+	return 0
+}
+
+func (x *MeasureTextResponse) GetAdvances() []float64 {
+	if x != nil {
+		return x.Advances
+	}
+	return nil
+}
+
+type FontInfoRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Font *Font `protobuf:"bytes,1,opt,name=font,proto3" json:"font,omitempty"`
+}
+
+func (x *FontInfoRequest) Reset() {
+	*x = FontInfoRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FontInfoRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FontInfoRequest) ProtoMessage() {}
+
+func (x *FontInfoRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FontInfoRequest.ProtoReflect.Descriptor instead.
+func (*FontInfoRequest) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *FontInfoRequest) GetFont() *Font {
+	if x != nil {
+		return x.Font
+	}
+	return nil
+}
+
+type FontInfoResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Family   string `protobuf:"bytes,1,opt,name=family,proto3" json:"family,omitempty"`
+	Style    string `protobuf:"bytes,2,opt,name=style,proto3" json:"style,omitempty"`
+	FullName string `protobuf:"bytes,3,opt,name=full_name,json=fullName,proto3" json:"full_name,omitempty"`
+	Version  string `protobuf:"bytes,4,opt,name=version,proto3" json:"version,omitempty"`
+	License  string `protobuf:"bytes,5,opt,name=license,proto3" json:"license,omitempty"`
+	// Vertical metrics in pixels at the requested size.
+	Ascent    float64 `protobuf:"fixed64,6,opt,name=ascent,proto3" json:"ascent,omitempty"`
+	Descent   float64 `protobuf:"fixed64,7,opt,name=descent,proto3" json:"descent,omitempty"`
+	LineGap   float64 `protobuf:"fixed64,8,opt,name=line_gap,json=lineGap,proto3" json:"line_gap,omitempty"`
+	CapHeight float64 `protobuf:"fixed64,9,opt,name=cap_height,json=capHeight,proto3" json:"cap_height,omitempty"`
+	XHeight   float64 `protobuf:"fixed64,10,opt,name=x_height,json=xHeight,proto3" json:"x_height,omitempty"`
+	// The number of runes the font has glyphs for.
+	RuneCount int32 `protobuf:"varint,11,opt,name=rune_count,json=runeCount,proto3" json:"rune_count,omitempty"`
+}
+
+func (x *FontInfoResponse) Reset() {
+	*x = FontInfoResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_filmore_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *FontInfoResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FontInfoResponse) ProtoMessage() {}
+
+func (x *FontInfoResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_filmore_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FontInfoResponse.ProtoReflect.Descriptor instead.
+func (*FontInfoResponse) Descriptor() ([]byte, []int) {
+	return file_filmore_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *FontInfoResponse) GetFamily() string {
+	if x != nil {
+		return x.Family
+	}
+	return ""
+}
+
+func (x *FontInfoResponse) GetStyle() string {
+	if x != nil {
+		return x.Style
+	}
+	return ""
+}
+
+func (x *FontInfoResponse) GetFullName() string {
+	if x != nil {
+		return x.FullName
+	}
+	return ""
+}
+
+func (x *FontInfoResponse) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *FontInfoResponse) GetLicense() string {
+	if x != nil {
+		return x.License
+	}
+	return ""
+}
+
+func (x *FontInfoResponse) GetAscent() float64 {
+	if x != nil {
+		return x.Ascent
+	}
+	return 0
+}
+
+func (x *FontInfoResponse) GetDescent() float64 {
+	if x != nil {
+		return x.Descent
+	}
+	return 0
+}
+
+func (x *FontInfoResponse) GetLineGap() float64 {
+	if x != nil {
+		return x.LineGap
+	}
+	return 0
+}
+
+func (x *FontInfoResponse) GetCapHeight() float64 {
+	if x != nil {
+		return x.CapHeight
+	}
+	return 0
+}
+
+func (x *FontInfoResponse) GetXHeight() float64 {
+	if x != nil {
+		return x.XHeight
+	}
+	return 0
+}
+
+func (x *FontInfoResponse) GetRuneCount() int32 {
+	if x != nil {
+		return x.RuneCount
+	}
+	return 0
+}
+
+var File_filmore_proto protoreflect.FileDescriptor
+
+var file_filmore_proto_rawDesc = []byte{
+	0x0a, 0x0d, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12,
+	0x07, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x22, 0x7a, 0x0a, 0x04, 0x46, 0x6f, 0x6e, 0x74,
+	0x12, 0x14, 0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0c, 0x48, 0x00,
+	0x52, 0x04, 0x64, 0x61, 0x74, 0x61, 0x12, 0x18, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79,
+	0x12, 0x24, 0x0a, 0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x0e, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x2e, 0x53, 0x74, 0x79, 0x6c, 0x65, 0x52,
+	0x05, 0x73, 0x74, 0x79, 0x6c, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x18, 0x04,
+	0x20, 0x01, 0x28, 0x05, 0x52, 0x04, 0x73, 0x69, 0x7a, 0x65, 0x42, 0x08, 0x0a, 0x06, 0x73, 0x6f,
+	0x75, 0x72, 0x63, 0x65, 0x22, 0x97, 0x02, 0x0a, 0x02, 0x4f, 0x70, 0x12, 0x24, 0x0a, 0x04, 0x6b,
+	0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x10, 0x2e, 0x66, 0x69, 0x6c, 0x6d,
+	0x6f, 0x72, 0x65, 0x2e, 0x4f, 0x70, 0x2e, 0x4b, 0x69, 0x6e, 0x64, 0x52, 0x04, 0x6b, 0x69, 0x6e,
+	0x64, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12,
+	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x1b, 0x0a,
+	0x09, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x78, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x08, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x58, 0x12, 0x1b, 0x0a, 0x09, 0x63, 0x6f,
+	0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x5f, 0x79, 0x18, 0x05, 0x20, 0x01, 0x28, 0x01, 0x52, 0x08, 0x63,
+	0x6f, 0x6e, 0x74, 0x72, 0x6f, 0x6c, 0x59, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72,
+	0x6f, 0x6c, 0x32, 0x5f, 0x78, 0x18, 0x06, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x63, 0x6f, 0x6e,
+	0x74, 0x72, 0x6f, 0x6c, 0x32, 0x58, 0x12, 0x1d, 0x0a, 0x0a, 0x63, 0x6f, 0x6e, 0x74, 0x72, 0x6f,
+	0x6c, 0x32, 0x5f, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x09, 0x63, 0x6f, 0x6e, 0x74,
+	0x72, 0x6f, 0x6c, 0x32, 0x59, 0x22, 0x57, 0x0a, 0x04, 0x4b, 0x69, 0x6e, 0x64, 0x12, 0x0b, 0x0a,
+	0x07, 0x4d, 0x4f, 0x56, 0x45, 0x5f, 0x54, 0x4f, 0x10, 0x00, 0x12, 0x0b, 0x0a, 0x07, 0x4c, 0x49,
+	0x4e, 0x45, 0x5f, 0x54, 0x4f, 0x10, 0x01, 0x12, 0x11, 0x0a, 0x0d, 0x51, 0x55, 0x41, 0x44, 0x5f,
+	0x43, 0x55, 0x52, 0x56, 0x45, 0x5f, 0x54, 0x4f, 0x10, 0x02, 0x12, 0x0e, 0x0a, 0x0a, 0x43, 0x4c,
+	0x4f, 0x53, 0x45, 0x5f, 0x50, 0x41, 0x54, 0x48, 0x10, 0x03, 0x12, 0x12, 0x0a, 0x0e, 0x43, 0x55,
+	0x42, 0x49, 0x43, 0x5f, 0x43, 0x55, 0x52, 0x56, 0x45, 0x5f, 0x54, 0x4f, 0x10, 0x04, 0x22, 0x87,
+	0x01, 0x0a, 0x08, 0x54, 0x65, 0x78, 0x74, 0x50, 0x61, 0x74, 0x68, 0x12, 0x1d, 0x0a, 0x03, 0x6f,
+	0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f,
+	0x72, 0x65, 0x2e, 0x4f, 0x70, 0x52, 0x03, 0x6f, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x12, 0x16, 0x0a, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x06, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x2e, 0x0a, 0x07, 0x73, 0x6f, 0x75, 0x72,
+	0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x66, 0x69, 0x6c, 0x6d,
+	0x6f, 0x72, 0x65, 0x2e, 0x47, 0x6c, 0x79, 0x70, 0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x52,
+	0x07, 0x73, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x73, 0x22, 0x6a, 0x0a, 0x0b, 0x47, 0x6c, 0x79, 0x70,
+	0x68, 0x53, 0x6f, 0x75, 0x72, 0x63, 0x65, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x75, 0x6e, 0x65, 0x5f,
+	0x69, 0x6e, 0x64, 0x65, 0x78, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72, 0x75, 0x6e,
+	0x65, 0x49, 0x6e, 0x64, 0x65, 0x78, 0x12, 0x14, 0x0a, 0x05, 0x67, 0x6c, 0x79, 0x70, 0x68, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x0d, 0x52, 0x05, 0x67, 0x6c, 0x79, 0x70, 0x68, 0x12, 0x14, 0x0a, 0x05,
+	0x73, 0x74, 0x61, 0x72, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x05, 0x52, 0x05, 0x73, 0x74, 0x61,
+	0x72, 0x74, 0x12, 0x10, 0x0a, 0x03, 0x65, 0x6e, 0x64, 0x18, 0x04, 0x20, 0x01, 0x28, 0x05, 0x52,
+	0x03, 0x65, 0x6e, 0x64, 0x22, 0x9e, 0x01, 0x0a, 0x11, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x54,
+	0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x66, 0x6f,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f,
+	0x72, 0x65, 0x2e, 0x46, 0x6f, 0x6e, 0x74, 0x52, 0x04, 0x66, 0x6f, 0x6e, 0x74, 0x12, 0x12, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x12, 0x0c, 0x0a, 0x01, 0x78, 0x18, 0x03, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x78, 0x12,
+	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x04, 0x20, 0x01, 0x28, 0x01, 0x52, 0x01, 0x79, 0x12, 0x22, 0x0a,
+	0x0d, 0x72, 0x69, 0x67, 0x68, 0x74, 0x5f, 0x74, 0x6f, 0x5f, 0x6c, 0x65, 0x66, 0x74, 0x18, 0x05,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0b, 0x72, 0x69, 0x67, 0x68, 0x74, 0x54, 0x6f, 0x4c, 0x65, 0x66,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x62, 0x69, 0x64, 0x69, 0x18, 0x06, 0x20, 0x01, 0x28, 0x08, 0x52,
+	0x04, 0x62, 0x69, 0x64, 0x69, 0x22, 0x49, 0x0a, 0x12, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x54,
+	0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x1d, 0x0a, 0x03, 0x6f,
+	0x70, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0b, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f,
+	0x72, 0x65, 0x2e, 0x4f, 0x70, 0x52, 0x03, 0x6f, 0x70, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69,
+	0x64, 0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68,
+	0x22, 0x4b, 0x0a, 0x12, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x54, 0x65, 0x78, 0x74, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x66, 0x6f, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x2e, 0x46,
+	0x6f, 0x6e, 0x74, 0x52, 0x04, 0x66, 0x6f, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x65, 0x78,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x74, 0x65, 0x78, 0x74, 0x22, 0x79, 0x0a,
+	0x13, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x05, 0x77, 0x69, 0x64, 0x74, 0x68, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x73,
+	0x63, 0x65, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x01, 0x52, 0x06, 0x61, 0x73, 0x63, 0x65,
+	0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x03, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x07, 0x64, 0x65, 0x73, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x1a, 0x0a, 0x08,
+	0x61, 0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x18, 0x04, 0x20, 0x03, 0x28, 0x01, 0x52, 0x08,
+	0x61, 0x64, 0x76, 0x61, 0x6e, 0x63, 0x65, 0x73, 0x22, 0x34, 0x0a, 0x0f, 0x46, 0x6f, 0x6e, 0x74,
+	0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x21, 0x0a, 0x04, 0x66,
+	0x6f, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0d, 0x2e, 0x66, 0x69, 0x6c, 0x6d,
+	0x6f, 0x72, 0x65, 0x2e, 0x46, 0x6f, 0x6e, 0x74, 0x52, 0x04, 0x66, 0x6f, 0x6e, 0x74, 0x22, 0xb7,
+	0x02, 0x0a, 0x10, 0x46, 0x6f, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x06, 0x66, 0x61, 0x6d, 0x69, 0x6c, 0x79, 0x12, 0x14, 0x0a, 0x05, 0x73,
+	0x74, 0x79, 0x6c, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x73, 0x74, 0x79, 0x6c,
+	0x65, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x75, 0x6c, 0x6c, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x75, 0x6c, 0x6c, 0x4e, 0x61, 0x6d, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x18, 0x0a, 0x07, 0x6c, 0x69, 0x63, 0x65,
+	0x6e, 0x73, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x6c, 0x69, 0x63, 0x65, 0x6e,
+	0x73, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x73, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x06, 0x20, 0x01,
+	0x28, 0x01, 0x52, 0x06, 0x61, 0x73, 0x63, 0x65, 0x6e, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x64, 0x65,
+	0x73, 0x63, 0x65, 0x6e, 0x74, 0x18, 0x07, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x64, 0x65, 0x73,
+	0x63, 0x65, 0x6e, 0x74, 0x12, 0x19, 0x0a, 0x08, 0x6c, 0x69, 0x6e, 0x65, 0x5f, 0x67, 0x61, 0x70,
+	0x18, 0x08, 0x20, 0x01, 0x28, 0x01, 0x52, 0x07, 0x6c, 0x69, 0x6e, 0x65, 0x47, 0x61, 0x70, 0x12,
+	0x1d, 0x0a, 0x0a, 0x63, 0x61, 0x70, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x01, 0x52, 0x09, 0x63, 0x61, 0x70, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x19,
+	0x0a, 0x08, 0x78, 0x5f, 0x68, 0x65, 0x69, 0x67, 0x68, 0x74, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x01,
+	0x52, 0x07, 0x78, 0x48, 0x65, 0x69, 0x67, 0x68, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x72, 0x75, 0x6e,
+	0x65, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x05, 0x52, 0x09, 0x72,
+	0x75, 0x6e, 0x65, 0x43, 0x6f, 0x75, 0x6e, 0x74, 0x2a, 0x3b, 0x0a, 0x05, 0x53, 0x74, 0x79, 0x6c,
+	0x65, 0x12, 0x0b, 0x0a, 0x07, 0x52, 0x45, 0x47, 0x55, 0x4c, 0x41, 0x52, 0x10, 0x00, 0x12, 0x08,
+	0x0a, 0x04, 0x42, 0x4f, 0x4c, 0x44, 0x10, 0x01, 0x12, 0x0a, 0x0a, 0x06, 0x49, 0x54, 0x41, 0x4c,
+	0x49, 0x43, 0x10, 0x02, 0x12, 0x0f, 0x0a, 0x0b, 0x42, 0x4f, 0x4c, 0x44, 0x5f, 0x49, 0x54, 0x41,
+	0x4c, 0x49, 0x43, 0x10, 0x03, 0x32, 0xdb, 0x01, 0x0a, 0x07, 0x46, 0x69, 0x6c, 0x6d, 0x6f, 0x72,
+	0x65, 0x12, 0x45, 0x0a, 0x0a, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x54, 0x65, 0x78, 0x74, 0x12,
+	0x1a, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72,
+	0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x66, 0x69,
+	0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x2e, 0x52, 0x65, 0x6e, 0x64, 0x65, 0x72, 0x54, 0x65, 0x78, 0x74,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x48, 0x0a, 0x0b, 0x4d, 0x65, 0x61, 0x73,
+	0x75, 0x72, 0x65, 0x54, 0x65, 0x78, 0x74, 0x12, 0x1b, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72,
+	0x65, 0x2e, 0x4d, 0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x2e, 0x4d,
+	0x65, 0x61, 0x73, 0x75, 0x72, 0x65, 0x54, 0x65, 0x78, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x3f, 0x0a, 0x08, 0x46, 0x6f, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x18,
+	0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x2e, 0x46, 0x6f, 0x6e, 0x74, 0x49, 0x6e, 0x66,
+	0x6f, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19, 0x2e, 0x66, 0x69, 0x6c, 0x6d, 0x6f,
+	0x72, 0x65, 0x2e, 0x46, 0x6f, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x42, 0x20, 0x5a, 0x1e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2f, 0x6a, 0x64, 0x66, 0x2f, 0x66, 0x69, 0x6c, 0x6d, 0x6f, 0x72, 0x65, 0x2f, 0x72, 0x70,
+	0x63, 0x3b, 0x72, 0x70, 0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_filmore_proto_rawDescOnce sync.Once
+	file_filmore_proto_rawDescData = file_filmore_proto_rawDesc
+)
+
+func file_filmore_proto_rawDescGZIP() []byte {
+	file_filmore_proto_rawDescOnce.Do(func() {
+		file_filmore_proto_rawDescData = protoimpl.X.CompressGZIP(file_filmore_proto_rawDescData)
+	})
+	return file_filmore_proto_rawDescData
+}
+
+var file_filmore_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_filmore_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_filmore_proto_goTypes = []any{
+	(Style)(0),                  // 0: filmore.Style
+	(Op_Kind)(0),                // 1: filmore.Op.Kind
+	(*Font)(nil),                // 2: filmore.Font
+	(*Op)(nil),                  // 3: filmore.Op
+	(*TextPath)(nil),            // 4: filmore.TextPath
+	(*GlyphSource)(nil),         // 5: filmore.GlyphSource
+	(*RenderTextRequest)(nil),   // 6: filmore.RenderTextRequest
+	(*RenderTextResponse)(nil),  // 7: filmore.RenderTextResponse
+	(*MeasureTextRequest)(nil),  // 8: filmore.MeasureTextRequest
+	(*MeasureTextResponse)(nil), // 9: filmore.MeasureTextResponse
+	(*FontInfoRequest)(nil),     // 10: filmore.FontInfoRequest
+	(*FontInfoResponse)(nil),    // 11: filmore.FontInfoResponse
+}
+var file_filmore_proto_depIdxs = []int32{
+	0,  // 0: filmore.Font.style:type_name -> filmore.Style
+	1,  // 1: filmore.Op.kind:type_name -> filmore.Op.Kind
+	3,  // 2: filmore.TextPath.ops:type_name -> filmore.Op
+	5,  // 3: filmore.TextPath.sources:type_name -> filmore.GlyphSource
+	2,  // 4: filmore.RenderTextRequest.font:type_name -> filmore.Font
+	3,  // 5: filmore.RenderTextResponse.ops:type_name -> filmore.Op
+	2,  // 6: filmore.MeasureTextRequest.font:type_name -> filmore.Font
+	2,  // 7: filmore.FontInfoRequest.font:type_name -> filmore.Font
+	6,  // 8: filmore.Filmore.RenderText:input_type -> filmore.RenderTextRequest
+	8,  // 9: filmore.Filmore.MeasureText:input_type -> filmore.MeasureTextRequest
+	10, // 10: filmore.Filmore.FontInfo:input_type -> filmore.FontInfoRequest
+	7,  // 11: filmore.Filmore.RenderText:output_type -> filmore.RenderTextResponse
+	9,  // 12: filmore.Filmore.MeasureText:output_type -> filmore.MeasureTextResponse
+	11, // 13: filmore.Filmore.FontInfo:output_type -> filmore.FontInfoResponse
+	11, // [11:14] is the sub-list for method output_type
+	8,  // [8:11] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
+}
+
+func init() { file_filmore_proto_init() }
+func file_filmore_proto_init() {
+	if File_filmore_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_filmore_proto_msgTypes[0].Exporter = func(v any, i int) any {
+			switch v := v.(*Font); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[1].Exporter = func(v any, i int) any {
+			switch v := v.(*Op); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[2].Exporter = func(v any, i int) any {
+			switch v := v.(*TextPath); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[3].Exporter = func(v any, i int) any {
+			switch v := v.(*GlyphSource); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[4].Exporter = func(v any, i int) any {
+			switch v := v.(*RenderTextRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[5].Exporter = func(v any, i int) any {
+			switch v := v.(*RenderTextResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[6].Exporter = func(v any, i int) any {
+			switch v := v.(*MeasureTextRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[7].Exporter = func(v any, i int) any {
+			switch v := v.(*MeasureTextResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[8].Exporter = func(v any, i int) any {
+			switch v := v.(*FontInfoRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_filmore_proto_msgTypes[9].Exporter = func(v any, i int) any {
+			switch v := v.(*FontInfoResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_filmore_proto_msgTypes[0].OneofWrappers = []any{
+		(*Font_Data)(nil),
+		(*Font_Family)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_filmore_proto_rawDesc,
+			NumEnums:      2,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_filmore_proto_goTypes,
+		DependencyIndexes: file_filmore_proto_depIdxs,
+		EnumInfos:         file_filmore_proto_enumTypes,
+		MessageInfos:      file_filmore_proto_msgTypes,
+	}.Build()
+	File_filmore_proto = out.File
+	file_filmore_proto_rawDesc = nil
+	file_filmore_proto_goTypes = nil
+	file_filmore_proto_depIdxs = nil
+}
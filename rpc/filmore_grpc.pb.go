@@ -0,0 +1,211 @@
+// Service definition for using filmore from other languages. A server
+// implements it by loading the requested font with filmore.NewFont, or
+// filmore.FindFont for a family name, and calling the library functions
+// named in each method's comment, as the reference server in
+// cmd/filmore-grpc does.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: filmore.proto
+
+package rpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Filmore_RenderText_FullMethodName  = "/filmore.Filmore/RenderText"
+	Filmore_MeasureText_FullMethodName = "/filmore.Filmore/MeasureText"
+	Filmore_FontInfo_FullMethodName    = "/filmore.Filmore/FontInfo"
+)
+
+// FilmoreClient is the client API for Filmore service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type FilmoreClient interface {
+	// RenderText lays text out with Font.CreateTextPathOptions.
+	RenderText(ctx context.Context, in *RenderTextRequest, opts ...grpc.CallOption) (*RenderTextResponse, error)
+	// MeasureText sizes text with Font.Measure and Font.Advances, without
+	// building its outline.
+	MeasureText(ctx context.Context, in *MeasureTextRequest, opts ...grpc.CallOption) (*MeasureTextResponse, error)
+	// FontInfo reports a font's names and vertical metrics.
+	FontInfo(ctx context.Context, in *FontInfoRequest, opts ...grpc.CallOption) (*FontInfoResponse, error)
+}
+
+type filmoreClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewFilmoreClient(cc grpc.ClientConnInterface) FilmoreClient {
+	return &filmoreClient{cc}
+}
+
+func (c *filmoreClient) RenderText(ctx context.Context, in *RenderTextRequest, opts ...grpc.CallOption) (*RenderTextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RenderTextResponse)
+	err := c.cc.Invoke(ctx, Filmore_RenderText_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filmoreClient) MeasureText(ctx context.Context, in *MeasureTextRequest, opts ...grpc.CallOption) (*MeasureTextResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeasureTextResponse)
+	err := c.cc.Invoke(ctx, Filmore_MeasureText_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *filmoreClient) FontInfo(ctx context.Context, in *FontInfoRequest, opts ...grpc.CallOption) (*FontInfoResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FontInfoResponse)
+	err := c.cc.Invoke(ctx, Filmore_FontInfo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FilmoreServer is the server API for Filmore service.
+// All implementations must embed UnimplementedFilmoreServer
+// for forward compatibility.
+type FilmoreServer interface {
+	// RenderText lays text out with Font.CreateTextPathOptions.
+	RenderText(context.Context, *RenderTextRequest) (*RenderTextResponse, error)
+	// MeasureText sizes text with Font.Measure and Font.Advances, without
+	// building its outline.
+	MeasureText(context.Context, *MeasureTextRequest) (*MeasureTextResponse, error)
+	// FontInfo reports a font's names and vertical metrics.
+	FontInfo(context.Context, *FontInfoRequest) (*FontInfoResponse, error)
+	mustEmbedUnimplementedFilmoreServer()
+}
+
+// UnimplementedFilmoreServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedFilmoreServer struct{}
+
+func (UnimplementedFilmoreServer) RenderText(context.Context, *RenderTextRequest) (*RenderTextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RenderText not implemented")
+}
+func (UnimplementedFilmoreServer) MeasureText(context.Context, *MeasureTextRequest) (*MeasureTextResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MeasureText not implemented")
+}
+func (UnimplementedFilmoreServer) FontInfo(context.Context, *FontInfoRequest) (*FontInfoResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FontInfo not implemented")
+}
+func (UnimplementedFilmoreServer) mustEmbedUnimplementedFilmoreServer() {}
+func (UnimplementedFilmoreServer) testEmbeddedByValue()                 {}
+
+// UnsafeFilmoreServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to FilmoreServer will
+// result in compilation errors.
+type UnsafeFilmoreServer interface {
+	mustEmbedUnimplementedFilmoreServer()
+}
+
+func RegisterFilmoreServer(s grpc.ServiceRegistrar, srv FilmoreServer) {
+	// If the following call pancis, it indicates UnimplementedFilmoreServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Filmore_ServiceDesc, srv)
+}
+
+func _Filmore_RenderText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RenderTextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilmoreServer).RenderText(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Filmore_RenderText_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilmoreServer).RenderText(ctx, req.(*RenderTextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Filmore_MeasureText_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeasureTextRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilmoreServer).MeasureText(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Filmore_MeasureText_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilmoreServer).MeasureText(ctx, req.(*MeasureTextRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Filmore_FontInfo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FontInfoRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FilmoreServer).FontInfo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Filmore_FontInfo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FilmoreServer).FontInfo(ctx, req.(*FontInfoRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// Filmore_ServiceDesc is the grpc.ServiceDesc for Filmore service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Filmore_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "filmore.Filmore",
+	HandlerType: (*FilmoreServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "RenderText",
+			Handler:    _Filmore_RenderText_Handler,
+		},
+		{
+			MethodName: "MeasureText",
+			Handler:    _Filmore_MeasureText_Handler,
+		},
+		{
+			MethodName: "FontInfo",
+			Handler:    _Filmore_FontInfo_Handler,
+		},
+	},
+	Streams:  []grpc.StreamDesc{},
+	Metadata: "filmore.proto",
+}
@@ -0,0 +1,148 @@
+package filmore
+
+import (
+	"fmt"
+	"html"
+	"io"
+	"sort"
+	"strings"
+)
+
+// A Scene is a document composed of paths, such as a label or poster built
+// from text set with several fonts and imported artwork. Its nodes form a
+// tree of groups, each of which can be moved and styled as a whole.
+type Scene struct {
+	// Width and Height give the size of the page, in pixels.
+	Width, Height float64
+	Nodes         []*Node
+}
+
+// A Node is a path or a group of nodes in a Scene.
+type Node struct {
+	// Name identifies the node, and is written as its id in SVG.
+	Name string
+	// Path is the outline the node draws, if any, beneath its children.
+	Path *TextPath
+	// Children are the nodes in the group, drawn in order of Z.
+	Children []*Node
+	// Transform places the node and its children within its parent, as for
+	// TextPath.Transform. The zero matrix leaves them in place.
+	Transform [6]float64
+	// Z orders the node among its siblings: nodes with higher Z are drawn
+	// later, over those with lower Z, and nodes with the same Z are drawn in
+	// the order they are listed.
+	Z int
+	// Style holds presentation attributes named as in SVG, such as "fill" or
+	// "stroke-width", which apply to the node and the children that don't
+	// set them themselves.
+	Style map[string]string
+}
+
+// WriteSVG writes the scene to w as an SVG document, with a <g> element for
+// each group.
+func (s *Scene) WriteSVG(w io.Writer) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s">`+"\n",
+		svgNumber(s.Width), svgNumber(s.Height))
+	for _, n := range drawOrder(s.Nodes) {
+		n.writeSVG(&b, "")
+	}
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeSVG writes n and its children to b, each line starting with indent.
+func (n *Node) writeSVG(b *strings.Builder, indent string) {
+	attrs := svgAttributes(n.Name, n.Style)
+	if n.Transform != ([6]float64{}) {
+		m := n.Transform
+		attrs += fmt.Sprintf(` transform="matrix(%s %s %s %s %s %s)"`,
+			svgNumber(m[0]), svgNumber(m[1]), svgNumber(m[2]), svgNumber(m[3]), svgNumber(m[4]), svgNumber(m[5]))
+	}
+	if len(n.Children) == 0 && n.Path != nil {
+		fmt.Fprintf(b, "%s<path%s d=\"%s\"/>\n", indent, attrs, n.Path.SVGPathData())
+		return
+	}
+	fmt.Fprintf(b, "%s<g%s>\n", indent, attrs)
+	if n.Path != nil {
+		fmt.Fprintf(b, "%s  <path d=\"%s\"/>\n", indent, n.Path.SVGPathData())
+	}
+	for _, c := range drawOrder(n.Children) {
+		c.writeSVG(b, indent+"  ")
+	}
+	fmt.Fprintf(b, "%s</g>\n", indent)
+}
+
+// WritePDF writes the scene to w as a one-page PDF document, with one point
+// per pixel, drawing its nodes in the same order and with the same
+// transforms as WriteSVG. Of the nodes' styles, only fill, fill-rule, stroke
+// and stroke-width are drawn, with colors given as #rgb, #rrggbb or a basic
+// color name such as black or white.
+func (s *Scene) WritePDF(w io.Writer) error {
+	var b strings.Builder
+	for _, n := range drawOrder(s.Nodes) {
+		if err := n.writePDF(&b, nil); err != nil {
+			return err
+		}
+	}
+	return writePDFDocument(w, s.Width, s.Height, b.String())
+}
+
+// writePDF writes the content stream operators that draw n and its children
+// to b, with style holding the attributes n inherits.
+func (n *Node) writePDF(b *strings.Builder, style map[string]string) error {
+	if len(n.Style) > 0 {
+		merged := map[string]string{}
+		for k, v := range style {
+			merged[k] = v
+		}
+		for k, v := range n.Style {
+			merged[k] = v
+		}
+		style = merged
+	}
+	b.WriteString("q\n")
+	if n.Transform != ([6]float64{}) {
+		m := n.Transform
+		fmt.Fprintf(b, "%s %s %s %s %s %s cm\n",
+			svgNumber(m[0]), svgNumber(m[1]), svgNumber(m[2]), svgNumber(m[3]), svgNumber(m[4]), svgNumber(m[5]))
+	}
+	if n.Path != nil {
+		if err := writePDFPath(b, n.Path, style); err != nil {
+			return err
+		}
+	}
+	for _, c := range drawOrder(n.Children) {
+		if err := c.writePDF(b, style); err != nil {
+			return err
+		}
+	}
+	b.WriteString("Q\n")
+	return nil
+}
+
+// svgAttributes returns the id and style attributes of an SVG element, each
+// preceded by a space, with the style attributes in order of name.
+func svgAttributes(id string, style map[string]string) string {
+	var b strings.Builder
+	if id != "" {
+		fmt.Fprintf(&b, ` id="%s"`, html.EscapeString(id))
+	}
+	names := make([]string, 0, len(style))
+	for name := range style {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fmt.Fprintf(&b, ` %s="%s"`, html.EscapeString(name), html.EscapeString(style[name]))
+	}
+	return b.String()
+}
+
+// drawOrder returns nodes in the order they are drawn.
+func drawOrder(nodes []*Node) []*Node {
+	sorted := append([]*Node(nil), nodes...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Z < sorted[j].Z })
+	return sorted
+}
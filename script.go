@@ -0,0 +1,80 @@
+package filmore
+
+import "unicode/utf8"
+
+// A ScriptPosition places a span of text on, above or below the baseline.
+type ScriptPosition int
+
+const (
+	// ScriptNone sets the span on the baseline at full size.
+	ScriptNone ScriptPosition = iota
+	// ScriptSuper sets the span as a superscript, as in "m²".
+	ScriptSuper
+	// ScriptSub sets the span as a subscript, as in "H₂O".
+	ScriptSub
+)
+
+// A TextSpan is a run of text laid out by CreateSpansPath.
+type TextSpan struct {
+	Text     string
+	Position ScriptPosition
+}
+
+// ScriptMetrics returns how the font's designer would size and place text set
+// at pos: the horizontal and vertical scale factors, and how far to move it
+// right and down from the pen position, in pixels. They come from the OS/2
+// table, or are typical values for fonts without them. For ScriptNone the
+// text is left as it is.
+func (f *Font) ScriptMetrics(pos ScriptPosition) (sx, sy, dx, dy float64) {
+	if pos == ScriptNone {
+		return 1, 1, 0, 0
+	}
+	// The subscript values start at offset 10 of the OS/2 table and the
+	// superscript values at 18: x size, y size, x offset and y offset, in
+	// font units, with both y offsets positive away from the baseline.
+	os2 := f.table("OS/2")
+	base, sign := 10, 1.0
+	if pos == ScriptSuper {
+		base, sign = 18, -1
+	}
+	em := float64(f.font.FUnitsPerEm())
+	xSize, ySize := i16(os2, base), i16(os2, base+2)
+	if xSize <= 0 || ySize <= 0 {
+		if pos == ScriptSuper {
+			return 0.65, 0.65, 0, -0.35 * f.EmSize()
+		}
+		return 0.65, 0.65, 0, 0.14 * f.EmSize()
+	}
+	return float64(xSize) / em, float64(ySize) / em,
+		f.fUnitsToPixels(i16(os2, base+4)), sign * f.fUnitsToPixels(i16(os2, base+6))
+}
+
+// CreateSpansPath lays spans out one after another from left to right,
+// starting from x, y on the baseline, each as by CreateTextPathOptions and
+// then scaled and shifted as ScriptMetrics gives for its Position. Each span
+// is shaped on its own, so there is no kerning between spans. With
+// opts.Attribute, the RuneIndex of each source counts runes from the start of
+// the first span.
+func (f *Font) CreateSpansPath(spans []TextSpan, x, y float64, opts *TextOptions) (TextPath, error) {
+	var result TextPath
+	runes := 0
+	for _, span := range spans {
+		p, err := f.CreateTextPathOptions(span.Text, 0, 0, opts)
+		sx, sy, dx, dy := f.ScriptMetrics(span.Position)
+		p.Scale(sx, sy)
+		p.Translate(x+result.Width+dx, y+dy)
+		for _, src := range p.Sources {
+			src.RuneIndex += runes
+			src.Start += len(result.PathOps)
+			src.End += len(result.PathOps)
+			result.Sources = append(result.Sources, src)
+		}
+		result.PathOps = append(result.PathOps, p.PathOps...)
+		result.Width += p.Width * sx
+		if err != nil {
+			return result, err
+		}
+		runes += utf8.RuneCountInString(span.Text)
+	}
+	return result, nil
+}
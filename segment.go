@@ -0,0 +1,154 @@
+package filmore
+
+import "math"
+
+// A JointStyle is the shape of the joints Segment cuts between pieces.
+type JointStyle int
+
+const (
+	// JointStraight cuts pieces apart along straight lines.
+	JointStraight JointStyle = iota
+	// JointDovetail joins pieces with flared tabs that slide together.
+	JointDovetail
+	// JointPuzzle joins pieces with round knobs, like a jigsaw puzzle.
+	JointPuzzle
+)
+
+// A Piece is one part of a path cut into sheets by Segment.
+type Piece struct {
+	// Row and Col give the piece's place in the grid of pieces.
+	Row, Col int
+	// Path is the piece's outline, placed on its sheet, with the sheet's
+	// top left corner at 0, 0.
+	Path TextPath
+	// X and Y give where the sheet's top left corner belongs when the pieces
+	// are assembled, in the coordinates of the original path.
+	X, Y float64
+}
+
+// Segment cuts p into pieces that each fit on a sheet of the given size, for
+// cut letters larger than the material they are cut from. The pieces are
+// laid out on a grid over p's bounding box and joined along the cuts with
+// tabs of the given style that lock them together; jointSize is the width
+// and depth of each tab, which is left out of the grid cells so that pieces
+// with tabs still fit their sheets. Grid cells that p doesn't reach are left
+// out, so pieces are returned row by row but may skip places.
+func (p *TextPath) Segment(sheetWidth, sheetHeight float64, joint JointStyle, jointSize float64) []Piece {
+	minX, minY, maxX, maxY, ok := polylineBounds(p.Flatten(defaultTolerance))
+	if !ok {
+		return nil
+	}
+	depth := 0.0
+	if joint != JointStraight {
+		depth = jointSize
+	}
+	cellW, cellH := sheetWidth-2*depth, sheetHeight-2*depth
+	if cellW <= 0 || cellH <= 0 {
+		return nil
+	}
+	cols := int(math.Max(1, math.Ceil((maxX-minX)/cellW)))
+	rows := int(math.Max(1, math.Ceil((maxY-minY)/cellH)))
+	// Center the grid on p, and let its outer edges clear p a little.
+	x0 := minX - (float64(cols)*cellW-(maxX-minX))/2
+	y0 := minY - (float64(rows)*cellH-(maxY-minY))/2
+	const margin = 1.0
+	xs := func(i int) float64 {
+		switch i {
+		case 0:
+			return x0 - margin
+		case cols:
+			return x0 + float64(cols)*cellW + margin
+		}
+		return x0 + float64(i)*cellW
+	}
+	ys := func(j int) float64 {
+		switch j {
+		case 0:
+			return y0 - margin
+		case rows:
+			return y0 + float64(rows)*cellH + margin
+		}
+		return y0 + float64(j)*cellH
+	}
+	// cut returns the cut between a and b, with a tab pushed to the side
+	// given by side unless the cut is an outer edge.
+	cut := func(a, b Point, inner bool, side float64) []Point {
+		if !inner {
+			return []Point{a, b}
+		}
+		return jointLine(a, b, joint, jointSize, side)
+	}
+	// Tabs alternate in direction like the squares of a checkerboard.
+	side := func(i, j int) float64 { return float64(1 - 2*((i+j)%2)) }
+	var pieces []Piece
+	for j := 0; j < rows; j++ {
+		for i := 0; i < cols; i++ {
+			// Each cut is made as the cell above or to the left of it makes
+			// it, so that neighbours fit together.
+			top := cut(Point{xs(i), ys(j)}, Point{xs(i + 1), ys(j)}, j > 0, side(i, j-1))
+			right := cut(Point{xs(i + 1), ys(j)}, Point{xs(i + 1), ys(j + 1)}, i < cols-1, side(i, j))
+			bottom := cut(Point{xs(i), ys(j + 1)}, Point{xs(i + 1), ys(j + 1)}, j < rows-1, side(i, j))
+			left := cut(Point{xs(i), ys(j)}, Point{xs(i), ys(j + 1)}, i > 0, side(i-1, j))
+			var loop []Point
+			loop = append(loop, top...)
+			loop = append(loop, right[1:]...)
+			for k := len(bottom) - 2; k >= 0; k-- {
+				loop = append(loop, bottom[k])
+			}
+			for k := len(left) - 2; k > 0; k-- {
+				loop = append(loop, left[k])
+			}
+			region := pathFromLoops([][]Point{loop})
+			piece := p.Intersect(&region)
+			if len(piece.PathOps) == 0 {
+				continue
+			}
+			sx, sy := x0+float64(i)*cellW-depth, y0+float64(j)*cellH-depth
+			piece = piece.translated(-sx, -sy)
+			pieces = append(pieces, Piece{Row: j, Col: i, Path: piece, X: sx, Y: sy})
+		}
+	}
+	return pieces
+}
+
+// jointLine returns the polyline of a cut from a to b with a tab in the
+// middle, jutting out to the right of the direction of travel if side is
+// positive and to the left if it is negative. Cuts too short for a tab stay
+// straight.
+func jointLine(a, b Point, joint JointStyle, size, side float64) []Point {
+	length := math.Hypot(b.X-a.X, b.Y-a.Y)
+	if joint == JointStraight || length < 3*size {
+		return []Point{a, b}
+	}
+	// Build the tab in coordinates along and across the cut, then map them.
+	ux, uy := (b.X-a.X)/length, (b.Y-a.Y)/length
+	nx, ny := -uy*side, ux*side
+	at := func(t, n float64) Point {
+		return Point{a.X + t*ux + n*nx, a.Y + t*uy + n*ny}
+	}
+	c := length / 2
+	pts := []Point{a}
+	switch joint {
+	case JointDovetail:
+		pts = append(pts,
+			at(c-size*0.35, 0),
+			at(c-size*0.5, size),
+			at(c+size*0.5, size),
+			at(c+size*0.35, 0))
+	case JointPuzzle:
+		// A round knob on a narrower neck.
+		r, neck := size/2, size/4
+		center := size - r
+		start := math.Asin(neck / r)
+		pts = append(pts, at(c-neck, 0))
+		const steps = 16
+		for k := 0; k <= steps; k++ {
+			// Sweep from the neck's near side, around the far side of the
+			// knob, to the neck's other side.
+			angle := -math.Pi + start + (2*math.Pi-2*start)*float64(k)/steps
+			pts = append(pts, at(c+r*math.Sin(angle), center+r*math.Cos(angle)))
+		}
+		pts = append(pts, at(c+neck, 0))
+	}
+	return append(pts, b)
+}
@@ -0,0 +1,36 @@
+package filmore
+
+// A Rect is an axis-aligned rectangle, in pixels.
+type Rect struct {
+	MinX, MinY, MaxX, MaxY float64
+}
+
+// SelectionRects returns the rectangles covering runes i up to but not
+// including j of s, laid out as CreateTextPath(s, x, y) would, for drawing a
+// selection highlight behind the text. Each rectangle spans the caret
+// positions at the ends of the range and the font's full ascent and descent,
+// so that highlights on neighbouring text line up whatever the glyphs. There
+// is one rectangle per line of the selection, and none if the range is
+// empty; i and j are clamped to the runes of s.
+func (f *Font) SelectionRects(s string, x, y float64, i, j int) []Rect {
+	positions := f.CaretPositions(s)
+	clamp := func(k int) int {
+		if k < 0 {
+			return 0
+		}
+		if k >= len(positions) {
+			return len(positions) - 1
+		}
+		return k
+	}
+	i, j = clamp(i), clamp(j)
+	if i >= j {
+		return nil
+	}
+	return []Rect{{
+		MinX: x + positions[i],
+		MinY: y - f.Ascent(),
+		MaxX: x + positions[j],
+		MaxY: y + f.Descent(),
+	}}
+}
@@ -0,0 +1,65 @@
+package filmore
+
+// The truetype package parses only the tables it needs for rendering. The
+// helpers here read the others straight from the font data. All readers
+// return zero for out-of-range offsets, so a truncated or malformed table
+// yields empty results instead of a panic.
+
+func u8(b []byte, i int) int {
+	if i < 0 || i >= len(b) {
+		return 0
+	}
+	return int(b[i])
+}
+
+func u16(b []byte, i int) int {
+	if i < 0 || i+2 > len(b) {
+		return 0
+	}
+	return int(b[i])<<8 | int(b[i+1])
+}
+
+func i16(b []byte, i int) int {
+	return int(int16(u16(b, i)))
+}
+
+func u32(b []byte, i int) int {
+	if i < 0 || i+4 > len(b) {
+		return 0
+	}
+	return int(b[i])<<24 | int(b[i+1])<<16 | int(b[i+2])<<8 | int(b[i+3])
+}
+
+// sub returns b[i:], or nil if i is out of range.
+func sub(b []byte, i int) []byte {
+	if i < 0 || i > len(b) {
+		return nil
+	}
+	return b[i:]
+}
+
+// sfntTable returns the table with the given tag from the font data, or nil
+// if there is no such table.
+func sfntTable(data []byte, tag string) []byte {
+	n := u16(data, 4)
+	for i := 0; i < n; i++ {
+		rec := 12 + 16*i
+		if rec+16 > len(data) {
+			break
+		}
+		if string(data[rec:rec+4]) != tag {
+			continue
+		}
+		off, length := u32(data, rec+8), u32(data, rec+12)
+		if off+length > len(data) {
+			return nil
+		}
+		return data[off : off+length]
+	}
+	return nil
+}
+
+// table returns the named table from f's font data, or nil.
+func (f *Font) table(tag string) []byte {
+	return sfntTable(f.data, tag)
+}
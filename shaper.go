@@ -0,0 +1,104 @@
+package filmore
+
+import "code.google.com/p/freetype-go/freetype/truetype"
+
+// A ShapedGlyph is a glyph chosen by a Shaper, with its advance and its
+// offset from the pen position in pixels. Positive YOffset moves the glyph
+// down.
+type ShapedGlyph struct {
+	Glyph truetype.Index
+	// Cluster is the index, in runes, of the first rune of the shaped text
+	// that the glyph represents.
+	Cluster                    int
+	XAdvance, XOffset, YOffset float64
+}
+
+// A Shaper turns a run of text into positioned glyphs of a font. Complex
+// scripts such as Arabic and Devanagari need a shaper that understands them
+// to select contextual forms and place marks; such shapers can wrap an
+// external engine, using Font.Data and Font.EmSize to hand it the font.
+//
+// Shape is given a run of runes that all have the same direction, in logical
+// order, and returns the glyphs in logical order too; the caller takes care
+// of reversing right-to-left runs for display. Advances should include any
+// kerning between the glyph and the one displayed to its right.
+type Shaper interface {
+	Shape(f *Font, text []rune, rtl bool) []ShapedGlyph
+}
+
+// basicShaper maps each rune to a glyph through the font's cmap, applies the
+// font's kerning, and attaches combining marks to their bases. Emoji
+// sequences are looked up as a whole, and invisible characters the font has
+// no glyph for are dropped. It is used when TextOptions.Shaper is nil.
+type basicShaper struct{}
+
+func (basicShaper) Shape(f *Font, text []rune, rtl bool) []ShapedGlyph {
+	glyphs := f.shapeAdvances(text, rtl)
+	f.attachMarks(text, glyphs, rtl)
+	return glyphs
+}
+
+// shapeAdvances chooses glyphs for text and sets their advances as
+// basicShaper does, giving marks no advance, but leaves the marks where they
+// are rather than attaching them, so that no outlines need to be loaded.
+func (f *Font) shapeAdvances(text []rune, rtl bool) []ShapedGlyph {
+	var glyphs []ShapedGlyph
+	add := func(index truetype.Index, cluster int) {
+		glyphs = append(glyphs, ShapedGlyph{
+			Glyph:    index,
+			Cluster:  cluster,
+			XAdvance: fUnitsToFloat64(f.font.HMetric(f.scale, index).AdvanceWidth),
+		})
+	}
+	gsub := f.table("GSUB")
+	starts := emojiClusters(text)
+	for k, start := range starts {
+		end := len(text)
+		if k+1 < len(starts) {
+			end = starts[k+1]
+		}
+		indices := make([]truetype.Index, end-start)
+		for i := range indices {
+			indices[i] = f.font.Index(text[start+i])
+		}
+		// Emoji sequences become a single glyph if the font has a ligature
+		// for them. Otherwise draw their visible parts one by one, or a
+		// single .notdef if the font lacks even the first of them.
+		if lig, ok := ligature(gsub, indices, "ccmp", "liga", "rlig"); ok {
+			add(lig, start)
+			continue
+		}
+		if len(indices) > 1 && indices[0] == 0 {
+			add(0, start)
+			continue
+		}
+		for i, index := range indices {
+			r := text[start+i]
+			if i > 0 && (r == zwj || isVariationSelector(r) || isTag(r)) {
+				continue
+			}
+			if index == 0 && (isDefaultIgnorable(r) || isEmojiModifier(r)) {
+				continue
+			}
+			add(index, start+i)
+		}
+	}
+	// Kern adjacent base glyphs, skipping over any marks between them.
+	prev := -1
+	for i := range glyphs {
+		if f.isMark(text[glyphs[i].Cluster], glyphs[i].Glyph) {
+			glyphs[i].XAdvance = 0
+			continue
+		}
+		if prev >= 0 {
+			// Right-to-left, the earlier glyph is displayed on the right.
+			left, right := &glyphs[prev], &glyphs[i]
+			if rtl {
+				left, right = right, left
+			}
+			left.XAdvance += fUnitsToFloat64(f.font.Kerning(f.scale, left.Glyph, right.Glyph))
+		}
+		prev = i
+	}
+	return glyphs
+}
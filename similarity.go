@@ -0,0 +1,67 @@
+package filmore
+
+import "math"
+
+// HausdorffDistance returns the symmetric Hausdorff distance between the
+// outlines of a and b: the farthest any point on one outline is from the
+// other outline. The result is accurate to within a few multiples of
+// tolerance. Two empty paths are at distance 0; an empty path is infinitely
+// far from a non-empty one.
+func HausdorffDistance(a, b *TextPath, tolerance float64) float64 {
+	if tolerance <= 0 {
+		tolerance = defaultTolerance
+	}
+	pa, pb := a.Flatten(tolerance), b.Flatten(tolerance)
+	if len(pa) == 0 || len(pb) == 0 {
+		if len(pa) == len(pb) {
+			return 0
+		}
+		return math.Inf(1)
+	}
+	return math.Max(directedHausdorff(pa, pb, 2*tolerance), directedHausdorff(pb, pa, 2*tolerance))
+}
+
+// directedHausdorff returns the largest distance from a point of a, sampled
+// at most step apart, to the nearest segment of b.
+func directedHausdorff(a, b [][]Point, step float64) float64 {
+	max := 0.0
+	nearest := func(p Point) {
+		min := math.Inf(1)
+		for _, poly := range b {
+			if len(poly) == 1 {
+				min = math.Min(min, math.Hypot(p.X-poly[0].X, p.Y-poly[0].Y))
+			}
+			for i := 1; i < len(poly); i++ {
+				min = math.Min(min, distToSegment(p, poly[i-1], poly[i]))
+			}
+		}
+		max = math.Max(max, min)
+	}
+	for _, poly := range a {
+		nearest(poly[0])
+		for i := 1; i < len(poly); i++ {
+			p0, p1 := poly[i-1], poly[i]
+			n := int(math.Ceil(math.Hypot(p1.X-p0.X, p1.Y-p0.Y) / step))
+			for j := 1; j <= n; j++ {
+				t := float64(j) / float64(n)
+				nearest(Point{p0.X + t*(p1.X-p0.X), p0.Y + t*(p1.Y-p0.Y)})
+			}
+		}
+	}
+	return max
+}
+
+// OutlineDistance lays out a and b at the same origin and returns the
+// Hausdorff distance between their outlines, in pixels. Visually confusable
+// strings, such as "rn" and "m" in many faces, have small distances.
+func (f *Font) OutlineDistance(a, b string) float64 {
+	pa, pb := f.CreateTextPath(a, 0, 0), f.CreateTextPath(b, 0, 0)
+	return HausdorffDistance(&pa, &pb, f.EmSize()/500)
+}
+
+// Confusable reports whether a and b render to outlines no farther apart than
+// tolerance, measured as a fraction of the em size. Tolerances between 0.05
+// and 0.1 flag typical homoglyph substitutions.
+func (f *Font) Confusable(a, b string, tolerance float64) bool {
+	return f.OutlineDistance(a, b) <= tolerance*f.EmSize()
+}
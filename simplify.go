@@ -0,0 +1,74 @@
+package filmore
+
+// Simplify returns p with fewer ops, staying within tolerance pixels of it,
+// to shrink SVG and other output. Runs of straight segments are thinned by
+// Douglas-Peucker simplification, which drops collinear points; curves that
+// bulge less than tolerance from their chords become straight segments, and
+// segments of no length are dropped. Other curves and ClosePaths are kept as
+// they are, and Sources are carried over.
+func (p *TextPath) Simplify(tolerance float64) TextPath {
+	result := TextPath{Width: p.Width, Height: p.Height}
+	// starts maps the index of each op that starts a contour to its index in
+	// result.
+	starts := make([]int, len(p.PathOps)+1)
+	// run is the straight run being drawn, starting from the last point
+	// already in result.
+	run := []Point{{}}
+	flush := func() {
+		last := run[len(run)-1]
+		if len(run) > 1 {
+			for _, pt := range appendSimplified(nil, run, tolerance)[1:] {
+				result.LineTo(pt.X, pt.Y)
+			}
+			result.LineTo(last.X, last.Y)
+		}
+		run = append(run[:0], last)
+	}
+	for i, op := range p.PathOps {
+		pen, end := run[len(run)-1], Point{op.X(), op.Y()}
+		switch op := op.(type) {
+		case MoveTo:
+			flush()
+			starts[i] = len(result.PathOps)
+			result.MoveTo(end.X, end.Y)
+			run[0] = end
+		case QuadCurveTo:
+			if distToSegment(Point{op.cx, op.cy}, pen, end) > tolerance {
+				flush()
+				result.PathOps = append(result.PathOps, op)
+				run[0] = end
+			} else if end != pen {
+				run = append(run, end)
+			}
+		case CubicCurveTo:
+			if distToSegment(Point{op.cx1, op.cy1}, pen, end) > tolerance ||
+				distToSegment(Point{op.cx2, op.cy2}, pen, end) > tolerance {
+				flush()
+				result.PathOps = append(result.PathOps, op)
+				run[0] = end
+			} else if end != pen {
+				run = append(run, end)
+			}
+		case ClosePath:
+			if end != pen {
+				run = append(run, end)
+			}
+			flush()
+			if n := len(result.PathOps) - 1; n >= 0 && result.PathOps[n] == Op(LineTo{end.X, end.Y}) {
+				result.PathOps = result.PathOps[:n]
+			}
+			result.ClosePath()
+		default:
+			if end != pen {
+				run = append(run, end)
+			}
+		}
+	}
+	flush()
+	starts[len(p.PathOps)] = len(result.PathOps)
+	for _, src := range p.Sources {
+		src.Start, src.End = starts[src.Start], starts[src.End]
+		result.Sources = append(result.Sources, src)
+	}
+	return result
+}
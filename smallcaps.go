@@ -0,0 +1,45 @@
+package filmore
+
+import (
+	"unicode"
+
+	"code.google.com/p/freetype-go/freetype/truetype"
+)
+
+// smallCaps turns the glyphs of lowercase runes into small capitals: the
+// font's own, from its smcp feature, or else its capitals scaled down to
+// the x-height. Lowercase letters whose capitals the font lacks are left as
+// they are.
+func smallCaps(glyphs []fontGlyph, runes []rune) {
+	smcp := map[*Font]map[truetype.Index]truetype.Index{}
+	for i := range glyphs {
+		g := &glyphs[i]
+		r := runes[g.Cluster]
+		if g.override != nil || !unicode.IsLower(r) {
+			continue
+		}
+		subs, ok := smcp[g.font]
+		if !ok {
+			subs = singleSubstitutions(g.font.table("GSUB"), "smcp")
+			smcp[g.font] = subs
+		}
+		if sc, ok := subs[g.Glyph]; ok {
+			g.Glyph = sc
+			g.XAdvance = fUnitsToFloat64(g.font.font.HMetric(g.font.scale, sc).AdvanceWidth)
+			continue
+		}
+		upper := unicode.ToUpper(r)
+		capHeight := g.font.CapHeight()
+		if upper == r || !g.font.HasGlyph(upper) || capHeight <= 0 {
+			continue
+		}
+		p, err := g.font.CreateGlyphPath(upper, 0, 0)
+		if err != nil {
+			continue
+		}
+		k := g.font.XHeight() / capHeight
+		p.Scale(k, k)
+		g.override = &p
+		g.XAdvance = p.Width * k
+	}
+}
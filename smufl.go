@@ -0,0 +1,155 @@
+package filmore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// A MusicFont is a music font following SMuFL, the Standard Music Font
+// Layout, such as Bravura or Petaluma, together with its metadata, for
+// drawing musical symbols by their canonical SMuFL names and placing them by
+// the font's anchors. Distances in SMuFL metadata are in staff spaces, a
+// quarter of the em; MusicFont converts them to pixels, with y pointing down.
+type MusicFont struct {
+	Font *Font
+	// runes maps glyph names to their code points.
+	runes map[string]rune
+	// anchors maps glyph names to their anchors, in staff spaces with y
+	// pointing up, as in the metadata.
+	anchors  map[string]map[string][2]float64
+	defaults map[string]float64
+}
+
+// The parts of the SMuFL font metadata and glyph names files that MusicFont
+// uses.
+type (
+	smuflMetadata struct {
+		EngravingDefaults map[string]interface{}          `json:"engravingDefaults"`
+		GlyphsWithAnchors map[string]map[string][]float64 `json:"glyphsWithAnchors"`
+		OptionalGlyphs    map[string]smuflGlyph           `json:"optionalGlyphs"`
+	}
+	smuflGlyph struct {
+		Codepoint string `json:"codepoint"`
+	}
+)
+
+// NewMusicFont returns f as a music font described by metadata, the font's
+// SMuFL metadata JSON, such as bravura_metadata.json, and glyphNames, the
+// glyphnames.json file of the SMuFL standard, which gives the code points of
+// the standard glyphs. glyphNames may be nil, in which case only the font's
+// optional glyphs, which its metadata lists with their code points, can be
+// drawn by name.
+func NewMusicFont(f *Font, metadata, glyphNames io.Reader) (*MusicFont, error) {
+	var meta smuflMetadata
+	if err := json.NewDecoder(metadata).Decode(&meta); err != nil {
+		return nil, fmt.Errorf("filmore: reading SMuFL metadata: %v", err)
+	}
+	names := map[string]smuflGlyph{}
+	if glyphNames != nil {
+		if err := json.NewDecoder(glyphNames).Decode(&names); err != nil {
+			return nil, fmt.Errorf("filmore: reading SMuFL glyph names: %v", err)
+		}
+	}
+	for name, g := range meta.OptionalGlyphs {
+		names[name] = g
+	}
+	m := &MusicFont{
+		Font:     f,
+		runes:    map[string]rune{},
+		anchors:  map[string]map[string][2]float64{},
+		defaults: map[string]float64{},
+	}
+	for name, g := range names {
+		cp, err := strconv.ParseUint(strings.TrimPrefix(g.Codepoint, "U+"), 16, 32)
+		if err != nil {
+			return nil, fmt.Errorf("filmore: SMuFL glyph %s has bad code point %q", name, g.Codepoint)
+		}
+		m.runes[name] = rune(cp)
+	}
+	for name, anchors := range meta.GlyphsWithAnchors {
+		m.anchors[name] = map[string][2]float64{}
+		for anchor, v := range anchors {
+			if len(v) != 2 {
+				return nil, fmt.Errorf("filmore: SMuFL anchor %s of %s is not a point", anchor, name)
+			}
+			m.anchors[name][anchor] = [2]float64{v[0], v[1]}
+		}
+	}
+	// Engraving defaults are distances, apart from a list of text font
+	// families, which is left out.
+	for name, v := range meta.EngravingDefaults {
+		if d, ok := v.(float64); ok {
+			m.defaults[name] = d
+		}
+	}
+	return m, nil
+}
+
+// StaffSpace returns the distance between two lines of a staff drawn with
+// the font, in pixels.
+func (m *MusicFont) StaffSpace() float64 {
+	return m.Font.EmSize() / 4
+}
+
+// Rune returns the code point of the glyph with the SMuFL name, such as
+// noteheadBlack or gClef, and whether the name is known.
+func (m *MusicFont) Rune(name string) (rune, bool) {
+	r, ok := m.runes[name]
+	return r, ok
+}
+
+// Anchor returns where the named anchor of the glyph with the SMuFL name
+// lies relative to the glyph's origin, in pixels, and whether the font's
+// metadata gives it. For example, the stemUpSE anchor of noteheadBlack is
+// where the bottom right corner of an up stem meets the notehead.
+func (m *MusicFont) Anchor(name, anchor string) (dx, dy float64, ok bool) {
+	a, ok := m.anchors[name][anchor]
+	if !ok {
+		return 0, 0, false
+	}
+	s := m.StaffSpace()
+	return a[0] * s, -a[1] * s, true
+}
+
+// EngravingDefault returns the engraving default with the SMuFL name, such
+// as stemThickness or staffLineThickness, in pixels, and whether the font's
+// metadata gives it.
+func (m *MusicFont) EngravingDefault(name string) (float64, bool) {
+	d, ok := m.defaults[name]
+	return d * m.StaffSpace(), ok
+}
+
+// Symbol returns the outline of the glyph with the SMuFL name, with its
+// origin at x, y. It fails if the name is unknown or the font has no glyph
+// for it.
+func (m *MusicFont) Symbol(name string, x, y float64) (TextPath, error) {
+	r, ok := m.runes[name]
+	if !ok {
+		return TextPath{}, fmt.Errorf("filmore: no SMuFL glyph named %s", name)
+	}
+	if !m.Font.HasGlyph(r) {
+		return TextPath{}, fmt.Errorf("filmore: font has no glyph for SMuFL %s (U+%04X)", name, r)
+	}
+	return m.Font.CreateTextPath(string(r), x, y), nil
+}
+
+// SymbolAt returns the outline of the glyph with the SMuFL name, placed so
+// that its named anchor lies at x, y, as for attaching a flag to the end of
+// a stem:
+//
+//	dx, dy, _ := m.Anchor("noteheadBlack", "stemUpSE")
+//	stemTop := y + dy - 3.5*m.StaffSpace()
+//	flag, err := m.SymbolAt("flag8thUp", "stemUpNW", x+dx, stemTop)
+//
+// It fails if the glyph cannot be drawn, as for Symbol, or the font's
+// metadata doesn't give the anchor.
+func (m *MusicFont) SymbolAt(name, anchor string, x, y float64) (TextPath, error) {
+	dx, dy, ok := m.Anchor(name, anchor)
+	if !ok {
+		return TextPath{}, fmt.Errorf("filmore: SMuFL glyph %s has no anchor %s", name, anchor)
+	}
+	return m.Symbol(name, x-dx, y-dy)
+}
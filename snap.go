@@ -0,0 +1,100 @@
+package filmore
+
+import (
+	"math"
+	"sort"
+)
+
+// A PixelSnap says how far layout goes in moving glyphs onto whole pixels,
+// trading exact metrics for crisper small text when rasterized.
+type PixelSnap int
+
+const (
+	// SnapNone places glyphs exactly where their metrics put them.
+	SnapNone PixelSnap = iota
+	// SnapOrigins moves the origin of each glyph to the nearest whole pixel.
+	SnapOrigins
+	// SnapExtrema also moves the flat edges and extremes of each glyph's
+	// outline, such as its baseline, x-height and the sides of its stems,
+	// to whole pixels, stretching the outline between them to fit.
+	SnapExtrema
+)
+
+// snapOrigin returns where a glyph whose origin is x, y is drawn under snap.
+func snapOrigin(x, y float64, snap PixelSnap) (float64, float64) {
+	if snap == SnapNone {
+		return x, y
+	}
+	return math.Round(x), math.Round(y)
+}
+
+// snapExtrema moves the extremes of the glyph outline drawn by ops to whole
+// pixels in place, as for SnapExtrema. An end point is taken to be an extreme
+// in y if the outline runs horizontally through it, and likewise in x.
+func snapExtrema(ops []Op) {
+	var xs, ys []float64
+	var x0, y0 float64
+	for _, op := range ops {
+		x1, y1 := op.X(), op.Y()
+		// The tangent at each end of a segment points to the control point
+		// nearest it, or for a line to the other end.
+		var sx, sy, ex, ey float64
+		switch op := op.(type) {
+		case MoveTo:
+			x0, y0 = x1, y1
+			continue
+		case QuadCurveTo:
+			sx, sy, ex, ey = op.cx, op.cy, op.cx, op.cy
+		case CubicCurveTo:
+			sx, sy, ex, ey = op.cx1, op.cy1, op.cx2, op.cy2
+		default:
+			sx, sy, ex, ey = x1, y1, x0, y0
+		}
+		if sy == y0 && sx != x0 {
+			ys = append(ys, y0)
+		}
+		if sx == x0 && sy != y0 {
+			xs = append(xs, x0)
+		}
+		if ey == y1 && ex != x1 {
+			ys = append(ys, y1)
+		}
+		if ex == x1 && ey != y1 {
+			xs = append(xs, x1)
+		}
+		x0, y0 = x1, y1
+	}
+	mapX, mapY := snapAxis(xs), snapAxis(ys)
+	p := TextPath{PathOps: ops}
+	snapped := p.mapPoints(func(x, y float64) (float64, float64) { return mapX(x), mapY(y) })
+	copy(ops, snapped.PathOps)
+}
+
+// snapAxis returns a function that moves each of values to the nearest
+// whole number, and other coordinates in proportion between them.
+func snapAxis(values []float64) func(float64) float64 {
+	if len(values) == 0 {
+		return func(v float64) float64 { return v }
+	}
+	sort.Float64s(values)
+	var from, to []float64
+	for _, v := range values {
+		if len(from) == 0 || v != from[len(from)-1] {
+			from = append(from, v)
+			to = append(to, math.Round(v))
+		}
+	}
+	return func(v float64) float64 {
+		i := sort.SearchFloat64s(from, v)
+		switch {
+		case i == 0:
+			return v + to[0] - from[0]
+		case i == len(from):
+			return v + to[i-1] - from[i-1]
+		case from[i] == v:
+			return to[i]
+		}
+		t := (v - from[i-1]) / (from[i] - from[i-1])
+		return lerp(to[i-1], to[i], t)
+	}
+}
@@ -0,0 +1,109 @@
+package filmore
+
+import (
+	"math"
+	"sort"
+)
+
+// spiralSteps is how many straight pieces CreateSpiralPath measures each
+// turn of its spiral in.
+const spiralSteps = 256
+
+// CreateSpiralPath lays s along an Archimedean spiral about cx, cy, reading
+// clockwise from the top of its outermost turn inward, with the tops of the
+// glyphs toward the outside. The text is scaled so that it exactly fills the
+// ring between outerRadius and innerRadius, with the descent of each turn
+// meeting the ascent of the turn inside it, so longer text is set smaller
+// and wound more tightly. The result's Width is the text's scaled width,
+// the length of the spiral it runs along.
+func (f *Font) CreateSpiralPath(s string, cx, cy, outerRadius, innerRadius float64) TextPath {
+	run, err := f.CreateGlyphRun(s, 0, 0, nil)
+	if err != nil || len(run) == 0 {
+		return TextPath{}
+	}
+	width := 0.0
+	for _, g := range run {
+		width += g.Advance
+	}
+	ascent, descent := f.Ascent(), f.Descent()
+	if width <= 0 || ascent+descent <= 0 || outerRadius <= innerRadius {
+		return TextPath{}
+	}
+	// spiral returns the spiral for text scaled by k: the radius of its
+	// baseline at the start, and how fast that shrinks per radian.
+	spiral := func(k float64) (start, shrink float64) {
+		return outerRadius - k*ascent, k * (ascent + descent) / (2 * math.Pi)
+	}
+	// length returns the length of the spiral for scale k, from the start to
+	// where its baseline is k*descent from innerRadius.
+	length := func(k float64) float64 {
+		start, shrink := spiral(k)
+		end := (start - innerRadius - k*descent) / shrink
+		if end <= 0 {
+			return 0
+		}
+		n := int(math.Ceil(end/(2*math.Pi)*spiralSteps)) + 1
+		step, total := end/float64(n), 0.0
+		for i := 0; i < n; i++ {
+			r := start - shrink*(float64(i)+0.5)*step
+			total += math.Hypot(r, shrink) * step
+		}
+		return total
+	}
+	// The spiral gets shorter as the text gets larger, so bisect for the
+	// scale at which the text is as long as the spiral.
+	lo, hi := 0.0, (outerRadius-innerRadius)/(ascent+descent)
+	for i := 0; i < 50; i++ {
+		k := (lo + hi) / 2
+		if k*width < length(k) {
+			lo = k
+		} else {
+			hi = k
+		}
+	}
+	k := lo
+	start, shrink := spiral(k)
+
+	// Tabulate the angle along the spiral against the distance along it.
+	var dists, angles []float64
+	dist, angle := 0.0, 0.0
+	step := 2 * math.Pi / spiralSteps
+	for dist <= k*width {
+		dists, angles = append(dists, dist), append(angles, angle)
+		r := start - shrink*(angle+step/2)
+		dist += math.Hypot(r, shrink) * step
+		angle += step
+	}
+	dists, angles = append(dists, dist), append(angles, angle)
+	angleAt := func(d float64) float64 {
+		i := sort.SearchFloat64s(dists, d)
+		if i == 0 {
+			return 0
+		}
+		if i == len(dists) {
+			i--
+		}
+		t := (d - dists[i-1]) / (dists[i] - dists[i-1])
+		return angles[i-1] + t*(angles[i]-angles[i-1])
+	}
+
+	result := TextPath{Width: k * width}
+	for _, g := range run {
+		// Each glyph is turned as a whole to follow the spiral at its middle.
+		mid := g.X + g.Advance/2
+		a := angleAt(k * mid)
+		r := start - shrink*a
+		sin, cos := math.Sin(a), math.Cos(a)
+		px, py := cx+r*sin, cy-r*cos
+		// The direction of travel, clockwise and inward.
+		tx, ty := r*cos-shrink*sin, r*sin+shrink*cos
+		l := math.Hypot(tx, ty)
+		tx, ty = tx/l, ty/l
+		p := g.Path.mapPoints(func(x, y float64) (float64, float64) {
+			u, v := k*(x-mid), k*y
+			return px + u*tx - v*ty, py + u*ty + v*tx
+		})
+		result.PathOps = append(result.PathOps, p.PathOps...)
+	}
+	return result
+}
@@ -0,0 +1,65 @@
+package filmore
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// A spritePlacement says where one glyph of a sprite sheet goes, in the
+// JSON written by WriteGlyphSprite.
+type spritePlacement struct {
+	Symbol    string  `json:"symbol"`
+	RuneIndex int     `json:"runeIndex"`
+	X         float64 `json:"x"`
+	Y         float64 `json:"y"`
+	Width     float64 `json:"width"`
+	Height    float64 `json:"height"`
+}
+
+// WriteGlyphSprite writes the distinct glyphs of run to svg as a sprite
+// sheet, and where each glyph goes to placements as JSON, so that a web page
+// can fetch each glyph's outline once and place it wherever it is used.
+//
+// Each distinct glyph becomes a <symbol> with an id of g0, g1 and so on, and
+// a viewBox fitted to its outline. placements receives an array of objects,
+// one per glyph of run with any ink, in run order:
+//
+//	{"symbol": "g0", "runeIndex": 0, "x": 10, "y": 2.5, "width": 8, "height": 11}
+//
+// giving the attributes of the <use> element that draws the glyph in place:
+//
+//	<use href="#g0" x="10" y="2.5" width="8" height="11"/>
+func WriteGlyphSprite(svg, placements io.Writer, run []PlacedGlyph) error {
+	symbols := map[string]string{}
+	list := []spritePlacement{}
+	if _, err := io.WriteString(svg, `<svg xmlns="http://www.w3.org/2000/svg" style="display:none">`+"\n"); err != nil {
+		return err
+	}
+	for _, g := range run {
+		// Glyphs are the same if their outlines are, relative to their
+		// origins.
+		outline := g.Path.translated(-g.X, -g.Y)
+		minX, minY, maxX, maxY, ok := polylineBounds(outline.Flatten(defaultTolerance))
+		if !ok {
+			continue
+		}
+		d := outline.SVGPathData()
+		id, ok := symbols[d]
+		if !ok {
+			id = "g" + strconv.Itoa(len(symbols))
+			symbols[d] = id
+			_, err := fmt.Fprintf(svg, `<symbol id="%s" viewBox="%s %s %s %s"><path d="%s"/></symbol>`+"\n",
+				id, svgNumber(minX), svgNumber(minY), svgNumber(maxX-minX), svgNumber(maxY-minY), d)
+			if err != nil {
+				return err
+			}
+		}
+		list = append(list, spritePlacement{id, g.RuneIndex, g.X + minX, g.Y + minY, maxX - minX, maxY - minY})
+	}
+	if _, err := io.WriteString(svg, "</svg>\n"); err != nil {
+		return err
+	}
+	return json.NewEncoder(placements).Encode(list)
+}
@@ -0,0 +1,150 @@
+package filmore
+
+import "math"
+
+// A LineCap is the shape Stroke gives the ends of open contours.
+type LineCap int
+
+const (
+	// CapButt ends the stroke square at the end of the contour.
+	CapButt LineCap = iota
+	// CapRound ends the stroke with a half disc around the end.
+	CapRound
+	// CapSquare ends the stroke square, half its width past the end.
+	CapSquare
+)
+
+// A LineJoin is the shape Stroke gives the outside of corners.
+type LineJoin int
+
+const (
+	// JoinMiter extends the edges of the stroke to meet in a point, unless
+	// the point would be more than miterLimit half-widths from the corner,
+	// in which case the corner is beveled.
+	JoinMiter LineJoin = iota
+	// JoinRound rounds the corner.
+	JoinRound
+	// JoinBevel cuts the corner off straight.
+	JoinBevel
+)
+
+// miterLimit is the longest that Stroke draws a miter, in half-widths of the
+// stroke, as in SVG.
+const miterLimit = 4
+
+// Stroke returns the area covered by drawing p's outline with a pen width
+// pixels wide, as a path to fill, for outlined or hollow lettering that
+// has to be cut or filled rather than stroked. Contours that end where they
+// start are joined all the way round; the ends of others are capped. The
+// result is a clean outline as from Combine, and its Width is that of p.
+func (p *TextPath) Stroke(width float64, cap LineCap, join LineJoin) TextPath {
+	r := width / 2
+	if r <= 0 {
+		return TextPath{Width: p.Width}
+	}
+	var pieces TextPath
+	sides := discSides(r)
+	for _, poly := range p.Flatten(defaultTolerance) {
+		// Drop repeated points, which have no direction.
+		pts := poly[:1]
+		for _, pt := range poly[1:] {
+			if pt != pts[len(pts)-1] {
+				pts = append(pts, pt)
+			}
+		}
+		if len(pts) < 2 {
+			if cap == CapRound {
+				pieces.appendDisc(pts[0], r, sides)
+			}
+			continue
+		}
+		closed := len(pts) > 2 && pts[0] == pts[len(pts)-1]
+		for i := 0; i+1 < len(pts); i++ {
+			pieces.appendSegmentRect(pts[i], pts[i+1], r)
+		}
+		n := len(pts)
+		for i := 1; i < n-1; i++ {
+			pieces.appendJoin(pts[i-1], pts[i], pts[i+1], r, join, sides)
+		}
+		if closed {
+			pieces.appendJoin(pts[n-2], pts[0], pts[1], r, join, sides)
+			continue
+		}
+		for _, end := range [][2]Point{{pts[1], pts[0]}, {pts[n-2], pts[n-1]}} {
+			// from is the neighbour of the end point, at.
+			from, at := end[0], end[1]
+			switch cap {
+			case CapRound:
+				pieces.appendDisc(at, r, sides)
+			case CapSquare:
+				l := math.Hypot(at.X-from.X, at.Y-from.Y)
+				dx, dy := (at.X-from.X)/l*r, (at.Y-from.Y)/l*r
+				pieces.appendSegmentRect(at, Point{at.X + dx, at.Y + dy}, r)
+			}
+		}
+	}
+	result := pieces.Union()
+	result.Width = p.Width
+	return result
+}
+
+// appendJoin appends the piece that fills the outside of the corner at b,
+// between the rectangles of a stroke r wide on each side of the segments ab
+// and bc. Round joins are drawn with the given number of sides to a full
+// turn.
+func (p *TextPath) appendJoin(a, b, c Point, r float64, join LineJoin, sides int) {
+	l1, l2 := math.Hypot(b.X-a.X, b.Y-a.Y), math.Hypot(c.X-b.X, c.Y-b.Y)
+	d1 := Point{(b.X - a.X) / l1, (b.Y - a.Y) / l1}
+	d2 := Point{(c.X - b.X) / l2, (c.Y - b.Y) / l2}
+	n1, n2 := Point{d1.Y * r, -d1.X * r}, Point{d2.Y * r, -d2.X * r}
+	// The outside of the corner is the side where the end of the first
+	// rectangle is behind the start of the second.
+	if n1.X*d2.X+n1.Y*d2.Y > 0 {
+		n1, n2 = Point{-n1.X, -n1.Y}, Point{-n2.X, -n2.Y}
+	}
+	cos := d1.X*d2.X + d1.Y*d2.Y
+	if math.Abs(cross(d1.X, d1.Y, d2.X, d2.Y)) < 1e-9 && cos > 0 {
+		return
+	}
+	pts := []Point{b, {b.X + n1.X, b.Y + n1.Y}}
+	switch {
+	case join == JoinRound:
+		// Sweep from n1 to n2 through the direction of ab, which also
+		// settles which way to go round where the stroke doubles back.
+		turn := math.Acos(math.Max(-1, math.Min(1, cos)))
+		if cross(n1.X, n1.Y, d1.X, d1.Y) < 0 {
+			turn = -turn
+		}
+		steps := int(math.Ceil(math.Abs(turn) * float64(sides) / (2 * math.Pi)))
+		for k := 1; k < steps; k++ {
+			sin, cos := math.Sincos(turn * float64(k) / float64(steps))
+			pts = append(pts, Point{b.X + n1.X*cos - n1.Y*sin, b.Y + n1.X*sin + n1.Y*cos})
+		}
+	case join == JoinMiter && cos > -1 && math.Sqrt(2/(1+cos)) <= miterLimit:
+		// The miter is 1/cos(θ/2) half-widths long for a turn through θ.
+		k := 1 / (1 + cos)
+		pts = append(pts, Point{b.X + (n1.X+n2.X)*k, b.Y + (n1.Y+n2.Y)*k})
+	}
+	pts = append(pts, Point{b.X + n2.X, b.Y + n2.Y})
+	p.appendOuterPolygon(pts)
+}
+
+// appendOuterPolygon appends the closed polygon through pts, wound like an
+// outer contour whichever way pts go round.
+func (p *TextPath) appendOuterPolygon(pts []Point) {
+	area := 0.0
+	for i, a := range pts {
+		b := pts[(i+1)%len(pts)]
+		area += cross(a.X, a.Y, b.X, b.Y)
+	}
+	if area < 0 {
+		for i, j := 0, len(pts)-1; i < j; i, j = i+1, j-1 {
+			pts[i], pts[j] = pts[j], pts[i]
+		}
+	}
+	p.MoveTo(pts[0].X, pts[0].Y)
+	for _, pt := range pts[1:] {
+		p.LineTo(pt.X, pt.Y)
+	}
+	p.LineTo(pts[0].X, pts[0].Y)
+}
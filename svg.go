@@ -0,0 +1,168 @@
+package filmore
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// svgNumber formats a coordinate for SVG, rounded to a thousandth of a pixel.
+func svgNumber(v float64) string {
+	v = math.Round(v*1000) / 1000
+	if v == 0 {
+		v = 0 // Avoid printing negative zero.
+	}
+	return strconv.FormatFloat(v, 'f', -1, 64)
+}
+
+// SVGPathData returns the ops of p as compact SVG path data, such as for the
+// d attribute of a <path> element. Coordinates are rounded to a thousandth of
+// a pixel, a command letter is left out when it repeats that of the op
+// before, and numbers are only separated by a space where a minus sign
+// doesn't separate them already.
+func (p *TextPath) SVGPathData() string {
+	var b strings.Builder
+	var last byte
+	// sep is whether a number written next needs a separator.
+	sep := false
+	command := func(c byte) {
+		if c != last || c == 'M' || c == 'Z' {
+			b.WriteByte(c)
+			sep = false
+		}
+		last = c
+	}
+	number := func(v float64) {
+		s := svgNumber(v)
+		if sep && s[0] != '-' {
+			b.WriteByte(' ')
+		}
+		b.WriteString(s)
+		sep = true
+	}
+	for _, op := range p.PathOps {
+		switch op := op.(type) {
+		case MoveTo:
+			command('M')
+		case LineTo:
+			command('L')
+		case ClosePath:
+			command('Z')
+			continue
+		case QuadCurveTo:
+			command('Q')
+			number(op.cx)
+			number(op.cy)
+		case CubicCurveTo:
+			command('C')
+			for _, v := range []float64{op.cx1, op.cy1, op.cx2, op.cy2} {
+				number(v)
+			}
+		}
+		number(op.X())
+		number(op.Y())
+	}
+	return b.String()
+}
+
+// SVGOptions says how WriteSVG draws paths.
+type SVGOptions struct {
+	// Fill and Stroke are the SVG paints the paths are drawn with, such as
+	// "#333" or "none". An empty Fill fills in black, and an empty Stroke
+	// leaves the outlines unstroked.
+	Fill, Stroke string
+	// StrokeWidth is the width of the stroke, in pixels. Zero means 1.
+	StrokeWidth float64
+	// Padding is the margin left around the paths, in pixels.
+	Padding float64
+	// IDs, if not nil, gives the id of each path's group.
+	IDs []string
+}
+
+// WriteSVG writes paths to w as a complete SVG document, with its viewBox
+// fitted to their bounds and any stroke, plus opts.Padding. Each path is a
+// <g> element, holding a <path> for each glyph if the path has Sources, as
+// from TextOptions.Attribute, and for each run of ops between them that no
+// glyph produced, such as those added by InsertPath, or else a single
+// <path>.
+func WriteSVG(w io.Writer, paths []TextPath, opts SVGOptions) error {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for i := range paths {
+		if len(paths[i].PathOps) == 0 {
+			continue
+		}
+		x0, y0, x1, y1 := paths[i].Bounds()
+		minX, minY = math.Min(minX, x0), math.Min(minY, y0)
+		maxX, maxY = math.Max(maxX, x1), math.Max(maxY, y1)
+	}
+	if math.IsInf(minX, 1) {
+		minX, minY, maxX, maxY = 0, 0, 0, 0
+	}
+	style := map[string]string{}
+	if opts.Fill != "" {
+		style["fill"] = opts.Fill
+	}
+	margin := opts.Padding
+	if opts.Stroke != "" {
+		width := opts.StrokeWidth
+		if width == 0 {
+			width = 1
+		}
+		style["stroke"] = opts.Stroke
+		style["stroke-width"] = svgNumber(width)
+		margin += width / 2
+	}
+	minX, minY, maxX, maxY = minX-margin, minY-margin, maxX+margin, maxY+margin
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="%s %s %[1]s %[2]s">`+"\n",
+		svgNumber(maxX-minX), svgNumber(maxY-minY), svgNumber(minX), svgNumber(minY))
+	fmt.Fprintf(&b, "<g%s>\n", svgAttributes("", style))
+	for i := range paths {
+		p := &paths[i]
+		id := ""
+		if i < len(opts.IDs) {
+			id = opts.IDs[i]
+		}
+		fmt.Fprintf(&b, "  <g%s>\n", svgAttributes(id, nil))
+		if len(p.Sources) == 0 {
+			fmt.Fprintf(&b, "    <path d=\"%s\"/>\n", p.SVGPathData())
+		}
+		for _, ops := range sourceRuns(p) {
+			glyph := TextPath{PathOps: ops}
+			fmt.Fprintf(&b, "    <path d=\"%s\"/>\n", glyph.SVGPathData())
+		}
+		b.WriteString("  </g>\n")
+	}
+	b.WriteString("</g>\n</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// sourceRuns splits the ops of p into runs in order: one for each of its
+// Sources with any ops, and one for each run of ops between or after them
+// that none covers.
+func sourceRuns(p *TextPath) [][]Op {
+	sources := append([]GlyphSource(nil), p.Sources...)
+	sort.SliceStable(sources, func(i, j int) bool { return sources[i].Start < sources[j].Start })
+	var runs [][]Op
+	at := 0
+	for _, src := range sources {
+		if src.Start > at {
+			runs = append(runs, p.PathOps[at:src.Start])
+			at = src.Start
+		}
+		// Overlapping sources give their shared ops to the first.
+		if src.End > at {
+			runs = append(runs, p.PathOps[at:src.End])
+			at = src.End
+		}
+	}
+	if at < len(p.PathOps) {
+		runs = append(runs, p.PathOps[at:])
+	}
+	return runs
+}
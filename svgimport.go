@@ -0,0 +1,378 @@
+package filmore
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ParseSVGPathData parses SVG path data, the d attribute of a <path> element,
+// into a TextPath, so that decorations drawn in other programs can be
+// combined, transformed and exported along with text. All of SVG's commands
+// are understood; arcs become cubic curves, and closing a sub-path becomes a
+// ClosePath.
+func ParseSVGPathData(d string) (TextPath, error) {
+	var p TextPath
+	s := svgScanner{s: d}
+	var cmd byte
+	// The current point, the start of the sub-path, and the last control
+	// point for the smooth curve commands.
+	var x, y, startX, startY, ctrlX, ctrlY float64
+	var lastCmd byte
+	for {
+		s.skipSpace()
+		if s.done() {
+			break
+		}
+		if c := s.s[s.i]; strings.IndexByte("MmLlHhVvCcSsQqTtAaZz", c) >= 0 {
+			cmd = c
+			s.i++
+		} else if cmd == 0 || cmd == 'Z' || cmd == 'z' {
+			return p, fmt.Errorf("filmore: expected a command at offset %d of %q", s.i, d)
+		} else if cmd == 'M' {
+			// Coordinates following a moveto continue as lineto.
+			cmd = 'L'
+		} else if cmd == 'm' {
+			cmd = 'l'
+		}
+		rel := cmd >= 'a'
+		dx, dy := 0.0, 0.0
+		if rel {
+			dx, dy = x, y
+		}
+		var v []float64
+		var err error
+		switch cmd {
+		case 'Z', 'z':
+			p.ClosePath()
+			x, y = startX, startY
+			lastCmd = 'Z'
+			continue
+		case 'M', 'm', 'L', 'l', 'T', 't':
+			v, err = s.numbers(2)
+		case 'H', 'h', 'V', 'v':
+			v, err = s.numbers(1)
+		case 'C', 'c':
+			v, err = s.numbers(6)
+		case 'S', 's', 'Q', 'q':
+			v, err = s.numbers(4)
+		case 'A', 'a':
+			v, err = s.arc()
+		}
+		if err != nil {
+			return p, err
+		}
+		upper := cmd &^ 0x20
+		// The reflection of the last control point, for smooth curves that
+		// follow curves of the same kind.
+		reflX, reflY := x, y
+		if (upper == 'S' && (lastCmd == 'C' || lastCmd == 'S')) || (upper == 'T' && (lastCmd == 'Q' || lastCmd == 'T')) {
+			reflX, reflY = 2*x-ctrlX, 2*y-ctrlY
+		}
+		switch upper {
+		case 'M':
+			x, y = v[0]+dx, v[1]+dy
+			startX, startY = x, y
+			p.MoveTo(x, y)
+		case 'L':
+			x, y = v[0]+dx, v[1]+dy
+			p.LineTo(x, y)
+		case 'H':
+			x = v[0] + dx
+			p.LineTo(x, y)
+		case 'V':
+			y = v[0] + dy
+			p.LineTo(x, y)
+		case 'C':
+			ctrlX, ctrlY = v[2]+dx, v[3]+dy
+			x, y = v[4]+dx, v[5]+dy
+			p.CubicCurveTo(x, y, v[0]+dx, v[1]+dy, ctrlX, ctrlY)
+		case 'S':
+			ctrlX, ctrlY = v[0]+dx, v[1]+dy
+			x, y = v[2]+dx, v[3]+dy
+			p.CubicCurveTo(x, y, reflX, reflY, ctrlX, ctrlY)
+		case 'Q':
+			ctrlX, ctrlY = v[0]+dx, v[1]+dy
+			x, y = v[2]+dx, v[3]+dy
+			p.QuadCurveTo(x, y, ctrlX, ctrlY)
+		case 'T':
+			ctrlX, ctrlY = reflX, reflY
+			x, y = v[0]+dx, v[1]+dy
+			p.QuadCurveTo(x, y, ctrlX, ctrlY)
+		case 'A':
+			x1, y1 := v[5]+dx, v[6]+dy
+			p.appendArc(x, y, v[0], v[1], v[2]*math.Pi/180, v[3] != 0, v[4] != 0, x1, y1)
+			x, y = x1, y1
+		}
+		lastCmd = upper
+	}
+	return p, nil
+}
+
+// ReadSVGPaths reads an SVG document from r and returns the outlines of all
+// its <path> elements together, transformed by their transform attributes
+// and those of the groups they are in. Only paths are read; other shapes,
+// styles and the document's viewBox are ignored.
+func ReadSVGPaths(r io.Reader) (TextPath, error) {
+	var result TextPath
+	d := xml.NewDecoder(r)
+	// The transform in effect within each open element.
+	stack := [][6]float64{{1, 0, 0, 1, 0, 0}}
+	for {
+		tok, err := d.Token()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return result, fmt.Errorf("filmore: reading SVG: %v", err)
+		}
+		switch tok := tok.(type) {
+		case xml.StartElement:
+			m := stack[len(stack)-1]
+			var data string
+			for _, a := range tok.Attr {
+				switch a.Name.Local {
+				case "transform":
+					t, err := parseSVGTransform(a.Value)
+					if err != nil {
+						return result, err
+					}
+					m = multiplyAffine(m, t)
+				case "d":
+					data = a.Value
+				}
+			}
+			stack = append(stack, m)
+			if tok.Name.Local != "path" {
+				continue
+			}
+			p, err := ParseSVGPathData(data)
+			if err != nil {
+				return result, err
+			}
+			p.Transform(m)
+			result.PathOps = append(result.PathOps, p.PathOps...)
+		case xml.EndElement:
+			stack = stack[:len(stack)-1]
+		}
+	}
+}
+
+// parseSVGTransform parses an SVG transform attribute into the matrix it
+// gives, in the form Transform takes.
+func parseSVGTransform(attr string) ([6]float64, error) {
+	m := [6]float64{1, 0, 0, 1, 0, 0}
+	rest := attr
+	for {
+		rest = strings.TrimLeft(rest, " \t\r\n,")
+		if rest == "" {
+			return m, nil
+		}
+		open, end := strings.IndexByte(rest, '('), strings.IndexByte(rest, ')')
+		if open < 0 || end < open {
+			return m, fmt.Errorf("filmore: bad SVG transform %q", attr)
+		}
+		name := strings.TrimSpace(rest[:open])
+		s := svgScanner{s: rest[open+1 : end]}
+		var v []float64
+		for {
+			s.skipSpace()
+			if s.done() {
+				break
+			}
+			n, err := s.number()
+			if err != nil {
+				return m, err
+			}
+			v = append(v, n)
+		}
+		rest = rest[end+1:]
+		var t [6]float64
+		switch {
+		case name == "matrix" && len(v) == 6:
+			copy(t[:], v)
+		case name == "translate" && len(v) == 1:
+			t = [6]float64{1, 0, 0, 1, v[0], 0}
+		case name == "translate" && len(v) == 2:
+			t = [6]float64{1, 0, 0, 1, v[0], v[1]}
+		case name == "scale" && len(v) == 1:
+			t = [6]float64{v[0], 0, 0, v[0], 0, 0}
+		case name == "scale" && len(v) == 2:
+			t = [6]float64{v[0], 0, 0, v[1], 0, 0}
+		case name == "rotate" && (len(v) == 1 || len(v) == 3):
+			sin, cos := math.Sincos(v[0] * math.Pi / 180)
+			t = [6]float64{cos, sin, -sin, cos, 0, 0}
+			if len(v) == 3 {
+				cx, cy := v[1], v[2]
+				t[4], t[5] = cx-cx*cos+cy*sin, cy-cx*sin-cy*cos
+			}
+		case name == "skewX" && len(v) == 1:
+			t = [6]float64{1, 0, math.Tan(v[0] * math.Pi / 180), 1, 0, 0}
+		case name == "skewY" && len(v) == 1:
+			t = [6]float64{1, math.Tan(v[0] * math.Pi / 180), 0, 1, 0, 0}
+		default:
+			return m, fmt.Errorf("filmore: bad SVG transform %q", attr)
+		}
+		m = multiplyAffine(m, t)
+	}
+}
+
+// multiplyAffine returns the matrix that applies b and then a, for matrices
+// in the form Transform takes.
+func multiplyAffine(a, b [6]float64) [6]float64 {
+	return [6]float64{
+		a[0]*b[0] + a[2]*b[1],
+		a[1]*b[0] + a[3]*b[1],
+		a[0]*b[2] + a[2]*b[3],
+		a[1]*b[2] + a[3]*b[3],
+		a[0]*b[4] + a[2]*b[5] + a[4],
+		a[1]*b[4] + a[3]*b[5] + a[5],
+	}
+}
+
+// appendArc appends cubic curves approximating the elliptical arc of an SVG
+// path's A command from x0, y0 to x1, y1, following the SVG specification's
+// conversion from its endpoint form to its center form.
+func (p *TextPath) appendArc(x0, y0, rx, ry, phi float64, large, sweep bool, x1, y1 float64) {
+	rx, ry = math.Abs(rx), math.Abs(ry)
+	if x0 == x1 && y0 == y1 {
+		return
+	}
+	if rx == 0 || ry == 0 {
+		p.LineTo(x1, y1)
+		return
+	}
+	sinPhi, cosPhi := math.Sincos(phi)
+	// The midpoint between the ends, in the ellipse's own axes.
+	mx, my := (x0-x1)/2, (y0-y1)/2
+	px, py := cosPhi*mx+sinPhi*my, -sinPhi*mx+cosPhi*my
+	// Radii too small to reach are scaled up until they do.
+	if l := px*px/(rx*rx) + py*py/(ry*ry); l > 1 {
+		rx, ry = rx*math.Sqrt(l), ry*math.Sqrt(l)
+	}
+	num := rx*rx*ry*ry - rx*rx*py*py - ry*ry*px*px
+	den := rx*rx*py*py + ry*ry*px*px
+	k := math.Sqrt(math.Max(0, num/den))
+	if large == sweep {
+		k = -k
+	}
+	cxp, cyp := k*rx*py/ry, -k*ry*px/rx
+	cx := cosPhi*cxp - sinPhi*cyp + (x0+x1)/2
+	cy := sinPhi*cxp + cosPhi*cyp + (y0+y1)/2
+	angle := func(ux, uy float64) float64 { return math.Atan2(uy, ux) }
+	start := angle((px-cxp)/rx, (py-cyp)/ry)
+	delta := angle((-px-cxp)/rx, (-py-cyp)/ry) - start
+	if sweep && delta < 0 {
+		delta += 2 * math.Pi
+	} else if !sweep && delta > 0 {
+		delta -= 2 * math.Pi
+	}
+	// Each piece of at most a quarter turn is drawn as a cubic curve.
+	n := int(math.Ceil(math.Abs(delta) / (math.Pi / 2)))
+	step := delta / float64(n)
+	h := 4.0 / 3 * math.Tan(step/4)
+	point := func(a float64) (x, y, dx, dy float64) {
+		sin, cos := math.Sincos(a)
+		ex, ey := rx*cos, ry*sin
+		tx, ty := -rx*sin, ry*cos
+		return cx + cosPhi*ex - sinPhi*ey, cy + sinPhi*ex + cosPhi*ey,
+			cosPhi*tx - sinPhi*ty, sinPhi*tx + cosPhi*ty
+	}
+	ax, ay, adx, ady := point(start)
+	for i := 1; i <= n; i++ {
+		bx, by, bdx, bdy := point(start + float64(i)*step)
+		if i == n {
+			bx, by = x1, y1
+		}
+		p.CubicCurveTo(bx, by, ax+h*adx, ay+h*ady, bx-h*bdx, by-h*bdy)
+		ax, ay, adx, ady = bx, by, bdx, bdy
+	}
+}
+
+// svgScanner reads the numbers and flags of SVG path data and attributes.
+type svgScanner struct {
+	s string
+	i int
+}
+
+func (s *svgScanner) done() bool { return s.i >= len(s.s) }
+
+// skipSpace skips whitespace and commas.
+func (s *svgScanner) skipSpace() {
+	for !s.done() && strings.IndexByte(" \t\r\n,", s.s[s.i]) >= 0 {
+		s.i++
+	}
+}
+
+// number reads a number, which may run straight on from the one before it,
+// as in "1-2" or "0.5.5".
+func (s *svgScanner) number() (float64, error) {
+	s.skipSpace()
+	start := s.i
+	if !s.done() && (s.s[s.i] == '+' || s.s[s.i] == '-') {
+		s.i++
+	}
+	digits, dot := false, false
+	for !s.done() {
+		c := s.s[s.i]
+		if c >= '0' && c <= '9' {
+			digits = true
+		} else if c == '.' && !dot {
+			dot = true
+		} else {
+			break
+		}
+		s.i++
+	}
+	if digits && !s.done() && (s.s[s.i] == 'e' || s.s[s.i] == 'E') {
+		j := s.i + 1
+		if j < len(s.s) && (s.s[j] == '+' || s.s[j] == '-') {
+			j++
+		}
+		if j < len(s.s) && s.s[j] >= '0' && s.s[j] <= '9' {
+			for s.i = j; !s.done() && s.s[s.i] >= '0' && s.s[s.i] <= '9'; s.i++ {
+			}
+		}
+	}
+	if !digits {
+		return 0, fmt.Errorf("filmore: expected a number at offset %d of %q", start, s.s)
+	}
+	return strconv.ParseFloat(s.s[start:s.i], 64)
+}
+
+// numbers reads n numbers.
+func (s *svgScanner) numbers(n int) ([]float64, error) {
+	v := make([]float64, n)
+	for i := range v {
+		var err error
+		if v[i], err = s.number(); err != nil {
+			return nil, err
+		}
+	}
+	return v, nil
+}
+
+// arc reads the arguments of an arc command, whose flags may be written
+// without separators, as in "a5 5 0 0110 10".
+func (s *svgScanner) arc() ([]float64, error) {
+	v, err := s.numbers(3)
+	if err != nil {
+		return nil, err
+	}
+	for k := 0; k < 2; k++ {
+		s.skipSpace()
+		if s.done() || (s.s[s.i] != '0' && s.s[s.i] != '1') {
+			return nil, fmt.Errorf("filmore: expected an arc flag at offset %d of %q", s.i, s.s)
+		}
+		v = append(v, float64(s.s[s.i]-'0'))
+		s.i++
+	}
+	end, err := s.numbers(2)
+	if err != nil {
+		return nil, err
+	}
+	return append(v, end...), nil
+}
@@ -0,0 +1,147 @@
+package filmore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// Style selects a face within a font family.
+type Style int
+
+const (
+	Regular    Style = 0
+	Bold       Style = 1
+	Italic     Style = 2
+	BoldItalic       = Bold | Italic
+)
+
+// fontDirs returns the directories that fonts are installed in on this
+// system, in the order fontconfig, macOS and Windows search them.
+func fontDirs() []string {
+	home := os.Getenv("HOME")
+	switch runtime.GOOS {
+	case "windows":
+		dirs := []string{filepath.Join(os.Getenv("WINDIR"), "Fonts")}
+		if local := os.Getenv("LOCALAPPDATA"); local != "" {
+			dirs = append(dirs, filepath.Join(local, "Microsoft", "Windows", "Fonts"))
+		}
+		return dirs
+	case "darwin":
+		return []string{
+			filepath.Join(home, "Library", "Fonts"),
+			"/Library/Fonts",
+			"/System/Library/Fonts",
+			"/Network/Library/Fonts",
+		}
+	default:
+		dirs := []string{}
+		if data := os.Getenv("XDG_DATA_HOME"); data != "" {
+			dirs = append(dirs, filepath.Join(data, "fonts"))
+		} else if home != "" {
+			dirs = append(dirs, filepath.Join(home, ".local", "share", "fonts"))
+		}
+		if home != "" {
+			dirs = append(dirs, filepath.Join(home, ".fonts"))
+		}
+		return append(dirs, "/usr/local/share/fonts", "/usr/share/fonts")
+	}
+}
+
+// FindFont returns the path of an installed font file in the given family
+// and style, for loading with NewFontFromFile. Family names are compared
+// without regard to case. If the family is installed but not in the style
+// asked for, the path of another of its faces is returned, preferring
+// Regular.
+func FindFont(family string, style Style) (string, error) {
+	best, bestStyle := "", Style(-1)
+	for _, dir := range fontDirs() {
+		filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+			if err != nil || info.IsDir() {
+				return nil
+			}
+			switch strings.ToLower(filepath.Ext(path)) {
+			case ".ttf", ".otf":
+			default:
+				return nil
+			}
+			names, s, ok := fontFileFamily(path, info.Size())
+			if !ok {
+				return nil
+			}
+			for _, name := range names {
+				if !strings.EqualFold(name, family) {
+					continue
+				}
+				if best == "" || s == style || (bestStyle != style && s == Regular) {
+					best, bestStyle = path, s
+				}
+				if bestStyle == style {
+					// An exact match can't be bettered.
+					return filepath.SkipAll
+				}
+				break
+			}
+			return nil
+		})
+		if bestStyle == style {
+			break
+		}
+	}
+	if best == "" {
+		return "", fmt.Errorf("filmore: no installed font in family %q", family)
+	}
+	return best, nil
+}
+
+// fontFileFamily reads the family names and the style of the font file at
+// path, which is size bytes long, without loading the whole file.
+func fontFileFamily(path string, size int64) (families []string, style Style, ok bool) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, 0, false
+	}
+	defer file.Close()
+	header := make([]byte, 12)
+	if _, err := file.ReadAt(header, 0); err != nil {
+		return nil, 0, false
+	}
+	dir := make([]byte, 12+16*u16(header, 4))
+	if _, err := file.ReadAt(dir, 0); err != nil {
+		return nil, 0, false
+	}
+	table := func(tag string) []byte {
+		for i, n := 0, u16(dir, 4); i < n; i++ {
+			rec := 12 + 16*i
+			if string(dir[rec:rec+4]) != tag {
+				continue
+			}
+			off, length := int64(u32(dir, rec+8)), int64(u32(dir, rec+12))
+			if off+length > size {
+				return nil
+			}
+			b := make([]byte, length)
+			if _, err := file.ReadAt(b, off); err != nil {
+				return nil
+			}
+			return b
+		}
+		return nil
+	}
+	name := table("name")
+	for _, id := range []int{nameTypoFamily, nameFamily} {
+		if s := nameString(name, id); s != "" {
+			families = append(families, s)
+		}
+	}
+	macStyle := u16(table("head"), 44)
+	if macStyle&1 != 0 {
+		style |= Bold
+	}
+	if macStyle&2 != 0 {
+		style |= Italic
+	}
+	return families, style, len(families) > 0
+}
@@ -0,0 +1,209 @@
+package filmore
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// A Template is a page of text with placeholders for the fields of a record,
+// such as a name badge, a certificate or a price tag, to be rendered once for
+// each record of a list. Templates are usually loaded from JSON with
+// LoadTemplate:
+//
+//	{
+//	  "width": 250, "height": 150,
+//	  "items": [
+//	    {"text": "{{name}}", "font": "DejaVu Sans", "bold": true, "size": 24,
+//	     "x": 125, "y": 70, "align": "center"},
+//	    {"text": "{{company}}", "font": "fonts/Body.ttf", "size": 12,
+//	     "x": 125, "y": 100, "align": "center", "fill": "#555"}
+//	  ]
+//	}
+type Template struct {
+	// Width and Height give the size of the page, in pixels.
+	Width  float64        `json:"width"`
+	Height float64        `json:"height"`
+	Items  []TemplateItem `json:"items"`
+
+	dir   string
+	fonts map[templateFont]*Font
+}
+
+// A TemplateItem is one piece of text on a Template.
+type TemplateItem struct {
+	// Text is the text to draw, in which each {{field}} is replaced by the
+	// value of that field of the record.
+	Text string `json:"text"`
+	// Font is the path of a font file, relative to the template's file, or
+	// failing that the family name of an installed font, as for FindFont.
+	Font   string `json:"font"`
+	Bold   bool   `json:"bold"`
+	Italic bool   `json:"italic"`
+	Size   int    `json:"size"`
+	// X and Y give where the text goes on the baseline, and Align which part
+	// of it goes there: "left", the default, "center" or "right".
+	X     float64 `json:"x"`
+	Y     float64 `json:"y"`
+	Align string  `json:"align"`
+	// Fill is the color of the text, in any form SVG accepts. It defaults to
+	// black.
+	Fill string `json:"fill"`
+}
+
+// templateFont identifies a loaded font of a Template.
+type templateFont struct {
+	name  string
+	style Style
+	size  int
+}
+
+// LoadTemplate reads a Template from the JSON file at filename. Font files
+// named by its items are found relative to the directory of filename.
+func LoadTemplate(filename string) (*Template, error) {
+	data, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	t := &Template{}
+	if err := json.Unmarshal(data, t); err != nil {
+		return nil, fmt.Errorf("filmore: reading template %s: %v", filename, err)
+	}
+	t.dir = filepath.Dir(filename)
+	return t, nil
+}
+
+// WriteSVG renders t for one record, given as a map from field names to
+// values, and writes it to w as an SVG document. It fails if the text of an
+// item names a field the record doesn't have.
+func (t *Template) WriteSVG(w io.Writer, record map[string]string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, `<svg xmlns="http://www.w3.org/2000/svg" width="%s" height="%s" viewBox="0 0 %[1]s %[2]s">`+"\n",
+		svgNumber(t.Width), svgNumber(t.Height))
+	for i := range t.Items {
+		item := &t.Items[i]
+		p, err := t.render(item, record)
+		if err != nil {
+			return err
+		}
+		fill := item.Fill
+		if fill == "" {
+			fill = "black"
+		}
+		fmt.Fprintf(&b, `<path fill="%s" d="%s"/>`+"\n", html.EscapeString(fill), p.SVGPathData())
+	}
+	b.WriteString("</svg>\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WritePDF renders t for one record, as for WriteSVG, and writes it to w as
+// a one-page PDF document with one point per pixel. Fill colors must be
+// given in a form WritePDF understands: #rgb, #rrggbb or a basic color
+// name such as black or white.
+func (t *Template) WritePDF(w io.Writer, record map[string]string) error {
+	var b strings.Builder
+	for i := range t.Items {
+		item := &t.Items[i]
+		p, err := t.render(item, record)
+		if err != nil {
+			return err
+		}
+		if err := writePDFPath(&b, &p, map[string]string{"fill": item.Fill}); err != nil {
+			return err
+		}
+	}
+	return writePDFDocument(w, t.Width, t.Height, b.String())
+}
+
+// render returns the outline of item filled in from record.
+func (t *Template) render(item *TemplateItem, record map[string]string) (TextPath, error) {
+	if item.Size <= 0 {
+		return TextPath{}, fmt.Errorf("filmore: template item %q has size %d", item.Text, item.Size)
+	}
+	text, err := fillPlaceholders(item.Text, record)
+	if err != nil {
+		return TextPath{}, err
+	}
+	f, err := t.font(item)
+	if err != nil {
+		return TextPath{}, err
+	}
+	x := item.X
+	switch item.Align {
+	case "", "left":
+	case "center":
+		w, _, _ := f.Measure(text)
+		x -= w / 2
+	case "right":
+		w, _, _ := f.Measure(text)
+		x -= w
+	default:
+		return TextPath{}, fmt.Errorf("filmore: unknown alignment %q", item.Align)
+	}
+	return f.CreateTextPath(text, x, item.Y), nil
+}
+
+// font returns the font of item, loading it the first time it is used.
+func (t *Template) font(item *TemplateItem) (*Font, error) {
+	var style Style
+	if item.Bold {
+		style |= Bold
+	}
+	if item.Italic {
+		style |= Italic
+	}
+	key := templateFont{item.Font, style, item.Size}
+	if f, ok := t.fonts[key]; ok {
+		return f, nil
+	}
+	path := item.Font
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(t.dir, path)
+	}
+	if _, err := os.Stat(path); err != nil {
+		if path, err = FindFont(item.Font, style); err != nil {
+			return nil, err
+		}
+	}
+	f, err := NewFontFromFile(path, item.Size)
+	if err != nil {
+		return nil, err
+	}
+	if t.fonts == nil {
+		t.fonts = map[templateFont]*Font{}
+	}
+	t.fonts[key] = f
+	return f, nil
+}
+
+// fillPlaceholders returns s with each {{field}} replaced by the value of
+// that field in record. Spaces around the field name are ignored.
+func fillPlaceholders(s string, record map[string]string) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.Index(s, "{{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}}")
+		if end < 0 {
+			break
+		}
+		name := strings.TrimSpace(s[start+2 : start+end])
+		value, ok := record[name]
+		if !ok {
+			return "", fmt.Errorf("filmore: record has no field %q", name)
+		}
+		b.WriteString(s[:start])
+		b.WriteString(value)
+		s = s[start+end+2:]
+	}
+	b.WriteString(s)
+	return b.String(), nil
+}
@@ -1,6 +1,8 @@
 package filmore
 
 import (
+	"io"
+	"io/fs"
 	"log"
 
 	"io/ioutil"
@@ -22,10 +24,23 @@ type op struct{ x, y float64 }
 
 type MoveTo op
 type LineTo op
+
+// ClosePath draws a straight line back to the start of the contour, closing
+// it. Its X and Y, like its ControlX and ControlY, give that point, so code
+// that treats it as a LineTo draws the same shape.
+type ClosePath op
+
 type QuadCurveTo struct {
 	x, y, cx, cy float64
 }
 
+// CubicCurveTo draws a cubic Bézier curve, such as from SVG or PostScript
+// outlines. Its ControlX and ControlY give the first control point, and
+// Control2X and Control2Y the second.
+type CubicCurveTo struct {
+	x, y, cx1, cy1, cx2, cy2 float64
+}
+
 func (o MoveTo) X() float64        { return o.x }
 func (o MoveTo) Y() float64        { return o.y }
 func (o MoveTo) ControlX() float64 { return o.x }
@@ -36,20 +51,72 @@ func (o LineTo) Y() float64        { return o.y }
 func (o LineTo) ControlX() float64 { return o.x }
 func (o LineTo) ControlY() float64 { return o.y }
 
+func (o ClosePath) X() float64        { return o.x }
+func (o ClosePath) Y() float64        { return o.y }
+func (o ClosePath) ControlX() float64 { return o.x }
+func (o ClosePath) ControlY() float64 { return o.y }
+
 func (o QuadCurveTo) X() float64        { return o.x }
 func (o QuadCurveTo) Y() float64        { return o.y }
 func (o QuadCurveTo) ControlX() float64 { return o.cx }
 func (o QuadCurveTo) ControlY() float64 { return o.cy }
 
+func (o CubicCurveTo) X() float64         { return o.x }
+func (o CubicCurveTo) Y() float64         { return o.y }
+func (o CubicCurveTo) ControlX() float64  { return o.cx1 }
+func (o CubicCurveTo) ControlY() float64  { return o.cy1 }
+func (o CubicCurveTo) Control2X() float64 { return o.cx2 }
+func (o CubicCurveTo) Control2Y() float64 { return o.cy2 }
+
 type Font struct {
 	font     *truetype.Font
 	glyphBuf *truetype.GlyphBuf
 	scale    int32
+	// data is the raw font file, for the tables truetype doesn't expose.
+	data []byte
+	// marks caches the GPOS mark attachment data, parsed on first use, and
+	// marksLoaded records that it has been parsed, even if it was empty.
+	marks       []markToBase
+	marksLoaded bool
+	// overrides holds custom outlines registered with SetGlyphOverride.
+	overrides map[rune]glyphOverride
+	// emboldened caches glyph outlines made bold for TextOptions.Embolden.
+	emboldened map[emboldenKey]TextPath
+	// unmap releases the font data of a memory-mapped font.
+	unmap func() error
 }
 
 type TextPath struct {
 	PathOps []Op
 	Width   float64
+	// Height is the total vertical advance of vertically laid out text, and
+	// zero for horizontal text.
+	Height float64
+	// Sources attributes runs of PathOps to the glyphs that produced them. It
+	// is only filled in when asked for with TextOptions.Attribute.
+	Sources []GlyphSource
+}
+
+// A GlyphSource attributes a run of a TextPath's ops to the glyph and rune
+// that produced them.
+type GlyphSource struct {
+	// RuneIndex is the position of the source rune in the laid out string,
+	// counted in runes rather than bytes.
+	RuneIndex int
+	Glyph     truetype.Index
+	// Start and End delimit the glyph's ops, PathOps[Start:End].
+	Start, End int
+}
+
+// Glyph returns the ops produced by the rune at runeIndex, or nil if the path
+// has no attribution for it.
+func (p *TextPath) Glyph(runeIndex int) []Op {
+	for _, src := range p.Sources {
+		if src.RuneIndex == runeIndex {
+			return p.PathOps[src.Start:src.End]
+		}
+	}
+	return nil
 }
 
 func (p *TextPath) MoveTo(x, y float64) {
@@ -60,20 +127,52 @@ func (p *TextPath) LineTo(x, y float64) {
 	p.PathOps = append(p.PathOps, LineTo{x, y})
 }
 
+// ClosePath closes the current contour with a straight line back to the
+// point of its MoveTo, or to the origin if it has none.
+func (p *TextPath) ClosePath() {
+	var x, y float64
+	for i := len(p.PathOps) - 1; i >= 0; i-- {
+		if m, ok := p.PathOps[i].(MoveTo); ok {
+			x, y = m.x, m.y
+			break
+		}
+	}
+	p.PathOps = append(p.PathOps, ClosePath{x, y})
+}
+
 func (p *TextPath) QuadCurveTo(x, y, controlX, controlY float64) {
 	p.PathOps = append(p.PathOps, QuadCurveTo{x, y, controlX, controlY})
 }
 
+func (p *TextPath) CubicCurveTo(x, y, control1X, control1Y, control2X, control2Y float64) {
+	p.PathOps = append(p.PathOps, CubicCurveTo{x, y, control1X, control1Y, control2X, control2Y})
+}
+
 func ttscale(fontSize int) int32 {
 	return int32(float64(fontSize) * float64(DPI) * (64.0 / 72.0))
 }
 
+// EmSize returns the size of the em square in pixels.
+func (f *Font) EmSize() float64 {
+	return float64(f.scale) / 64
+}
+
+// fUnitsToPixels converts a distance in the font's design units to pixels.
+func (f *Font) fUnitsToPixels(x int) float64 {
+	return float64(x) * f.EmSize() / float64(f.font.FUnitsPerEm())
+}
+
+// Data returns the font file the font was loaded from.
+func (f *Font) Data() []byte {
+	return f.data
+}
+
 func NewFont(fontData []byte, fontSize int) (*Font, error) {
 	font, err := truetype.Parse(fontData)
 	if err != nil {
 		return nil, err
 	}
-	return &Font{font, truetype.NewGlyphBuf(), ttscale(fontSize)}, nil
+	return &Font{font: font, glyphBuf: truetype.NewGlyphBuf(), scale: ttscale(fontSize), data: fontData}, nil
 }
 
 func NewFontFromFile(filename string, fontSize int) (*Font, error) {
@@ -84,6 +183,26 @@ func NewFontFromFile(filename string, fontSize int) (*Font, error) {
 	return NewFont(data, fontSize)
 }
 
+// NewFontFromReader is like NewFont, but reads the font data from r until
+// EOF, such as from a network response or an archive entry.
+func NewFontFromReader(r io.Reader, fontSize int) (*Font, error) {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	return NewFont(data, fontSize)
+}
+
+// NewFontFromFS is like NewFontFromFile, but reads the font from fsys, such as
+// an embed.FS holding fonts compiled into the program.
+func NewFontFromFS(fsys fs.FS, name string, fontSize int) (*Font, error) {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return nil, err
+	}
+	return NewFont(data, fontSize)
+}
+
 func fUnitsToFloat64(x int32) float64 {
 	scaled := x << 2
 	return float64(scaled/256) + float64(scaled%256)/256.0
@@ -110,7 +229,7 @@ func (textPath *TextPath) appendContour(ps []truetype.Point, dx, dy float64) {
 			if on0 {
 				textPath.LineTo(qX+dx, qY+dy)
 			} else {
-				textPath.QuadCurveTo(q0X+dx, q0Y+dy, qX+dx, qY+dy)
+				textPath.QuadCurveTo(qX+dx, qY+dy, q0X+dx, q0Y+dy)
 			}
 		} else {
 			if on0 {
@@ -118,7 +237,7 @@ func (textPath *TextPath) appendContour(ps []truetype.Point, dx, dy float64) {
 			} else {
 				midX := (q0X + qX) / 2
 				midY := (q0Y + qY) / 2
-				textPath.QuadCurveTo(q0X+dx, q0Y+dy, midX+dx, midY+dy)
+				textPath.QuadCurveTo(midX+dx, midY+dy, q0X+dx, q0Y+dy)
 			}
 		}
 		q0X, q0Y, on0 = qX, qY, on
@@ -127,20 +246,30 @@ func (textPath *TextPath) appendContour(ps []truetype.Point, dx, dy float64) {
 	if on0 {
 		textPath.LineTo(startX+dx, startY+dy)
 	} else {
-		textPath.QuadCurveTo(q0X+dx, q0Y+dy, startX+dx, startY+dy)
+		textPath.QuadCurveTo(startX+dx, startY+dy, q0X+dx, q0Y+dy)
 	}
 }
 
 func (f *Font) appendGlyphPath(glyph truetype.Index, dx, dy float64, textPath *TextPath) error {
-	if err := f.glyphBuf.Load(f.font, f.scale, glyph, truetype.NoHinting); err != nil {
+	if err := f.loadGlyph(glyph); err != nil {
 		return err
 	}
+	f.appendLoadedGlyph(dx, dy, textPath)
+	return nil
+}
+
+func (f *Font) loadGlyph(glyph truetype.Index) error {
+	return f.glyphBuf.Load(f.font, f.scale, glyph, truetype.NoHinting)
+}
+
+// appendLoadedGlyph appends the contours of the glyph most recently loaded
+// into f.glyphBuf.
+func (f *Font) appendLoadedGlyph(dx, dy float64, textPath *TextPath) {
 	e0 := 0
 	for _, e1 := range f.glyphBuf.End {
 		textPath.appendContour(f.glyphBuf.Point[e0:e1], dx, dy)
 		e0 = e1
 	}
-	return nil
 }
 
 // CreateTextPath creates a TextPath from the string s at x, y, and returns it.
@@ -150,22 +279,113 @@ func (f *Font) appendGlyphPath(glyph truetype.Index, dx, dy float64, textPath *T
 // For example, drawing a string that starts with a 'J' in an italic font may
 // affect pixels below and left of the point.
 func (f *Font) CreateTextPath(s string, x, y float64) TextPath {
-	result := TextPath{}
-	startx := x
-	prev, hasPrev := truetype.Index(0), false
-	for _, rune := range s {
-		index := f.font.Index(rune)
-		if hasPrev {
-			x += fUnitsToFloat64(f.font.Kerning(f.scale, prev, index))
-		}
-		err := f.appendGlyphPath(index, x, y, &result)
-		if err != nil {
-			log.Println(err)
-			return result
-		}
-		x += fUnitsToFloat64(f.font.HMetric(f.scale, index).AdvanceWidth)
-		result.Width = x - startx
-		prev, hasPrev = index, true
+	result, err := f.CreateTextPathOptions(s, x, y, nil)
+	if err != nil {
+		log.Println(err)
 	}
 	return result
 }
+
+// TextOptions adjusts how CreateTextPathOptions lays out text. The zero value
+// lays text out exactly as CreateTextPath does.
+type TextOptions struct {
+	// Attribute records in the path's Sources which ops came from which rune.
+	Attribute bool
+	// RightToLeft lays runes out from right to left, for Hebrew and Arabic
+	// text. The text then ends, rather than starts, at x: the right edge of
+	// the first rune's advance is at x, and Width extends leftward from it.
+	RightToLeft bool
+	// Bidi reorders mixed left-to-right and right-to-left text, such as
+	// English with embedded Hebrew, into visual order with the Unicode
+	// Bidirectional Algorithm, and mirrors brackets in right-to-left runs.
+	// The paragraph direction is given by RightToLeft.
+	Bidi bool
+	// Shaper selects and positions the glyphs for each run of text. If nil,
+	// runes are mapped to glyphs one to one through the font's cmap and
+	// kerned, and combining marks are attached to their base glyphs.
+	Shaper Shaper
+	// Missing says what to do with runes the font has no glyph for.
+	Missing MissingGlyphPolicy
+	// Replacement is the rune drawn in place of missing ones under the
+	// MissingReplace policy. If zero, U+FFFD REPLACEMENT CHARACTER is used.
+	Replacement rune
+	// ReplacementPath, if not nil, is drawn in place of missing runes under
+	// the MissingReplace policy instead of Replacement, such as a box that
+	// marks where text could not be shown. It is positioned relative to the
+	// glyph's origin on the baseline, and its Width is the glyph's advance.
+	ReplacementPath *TextPath
+	// BaselineOffset, if not nil, moves each glyph down by the pixels it
+	// returns for the distance from the left end of the line to the glyph's
+	// origin, for wavy or bouncing baselines. The glyphs are moved, not
+	// warped. Glyphs without an advance of their own, such as combining
+	// marks, move with the glyph before them.
+	BaselineOffset func(advance float64) float64
+	// Whitespace says whether to keep whitespace at the start and end of the
+	// line.
+	Whitespace WhitespacePolicy
+	// ShowInvisibles draws spaces as ·, no-break spaces as ° and tabs as →,
+	// centered in the space they take, as editors do to show whitespace.
+	// Marks the font has no glyph for are left out.
+	ShowInvisibles bool
+	// Embolden moves the outline of each glyph outward by this many pixels,
+	// or inward if negative, for a faux bold when the font has no bold face.
+	// Advances widen by twice as much, so the glyphs don't run together. The
+	// thickened outlines are made of straight segments, as from
+	// TextPath.Offset.
+	Embolden float64
+	// Slant leans each glyph to the right by this many radians from upright,
+	// or to the left if negative, for a faux italic when the font has no
+	// italic face. Glyphs are sheared about the baseline, so they stay where
+	// they are along it, and Width grows by how far the tallest glyph could
+	// lean past the last advance.
+	Slant float64
+	// HorizontalScale narrows glyphs and their advances by this factor if
+	// less than 1, or widens them if greater, such as 0.85 for condensed
+	// labels that must fit a fixed box. Heights are left alone. Zero leaves
+	// the text as the font draws it.
+	HorizontalScale float64
+	// SmallCaps draws lowercase letters as small capitals, for headers and
+	// labels. The font's own small capitals are used if it has them;
+	// otherwise its capitals are scaled down to its x-height.
+	SmallCaps bool
+	// Grid, if positive, rounds the coordinates of the outlines to multiples
+	// of it, as by TextPath.Quantize, such as 0.01 for two decimal places.
+	Grid float64
+	// Snap moves glyphs onto whole pixels, for crisper small text when
+	// rasterized. Advances are not changed, so the error doesn't build up
+	// along the line.
+	Snap PixelSnap
+}
+
+// A MissingGlyphPolicy says how to lay out runes that a font has no glyph
+// for. Invisible format characters are never considered missing.
+type MissingGlyphPolicy int
+
+const (
+	// MissingNotdef draws the font's .notdef glyph, often an empty box.
+	MissingNotdef MissingGlyphPolicy = iota
+	// MissingSkip leaves missing runes out, without advancing the pen.
+	MissingSkip
+	// MissingReplace draws TextOptions.Replacement or
+	// TextOptions.ReplacementPath instead.
+	MissingReplace
+	// MissingError makes CreateTextPathOptions fail with an error naming
+	// the first missing rune.
+	MissingError
+)
+
+// CreateTextPathOptions is like CreateTextPath, but lays the text out as
+// directed by opts, which may be nil. If a glyph fails to load, it returns the
+// path built so far along with the error.
+func (f *Font) CreateTextPathOptions(s string, x, y float64, opts *TextOptions) (TextPath, error) {
+	return layoutText([]rune(s), f.allRunes, x, y, opts)
+}
+
+// allRunes chooses f for every rune, for laying out text in a single font.
+func (f *Font) allRunes(runes []rune) []*Font {
+	fonts := make([]*Font, len(runes))
+	for i := range fonts {
+		fonts[i] = f
+	}
+	return fonts
+}
@@ -0,0 +1,26 @@
+package filmore
+
+import "math"
+
+// TileFill fills p with a repeating pattern: copies of motif offset by every
+// whole multiple of dx horizontally and dy vertically, clipped to the inside
+// of p. Because the grid is anchored at the origin rather than at p, paths
+// filled with the same motif and spacing line up with one another. The
+// result has p's Width.
+func (p *TextPath) TileFill(motif *TextPath, dx, dy float64) TextPath {
+	minX, minY, maxX, maxY, ok := polylineBounds(p.Flatten(defaultTolerance))
+	mminX, mminY, mmaxX, mmaxY, mok := polylineBounds(motif.Flatten(defaultTolerance))
+	if !ok || !mok || dx <= 0 || dy <= 0 {
+		return TextPath{Width: p.Width}
+	}
+	var tiles TextPath
+	for j := math.Ceil((minY - mmaxY) / dy); j <= math.Floor((maxY-mminY)/dy); j++ {
+		for i := math.Ceil((minX - mmaxX) / dx); i <= math.Floor((maxX-mminX)/dx); i++ {
+			tile := motif.translated(i*dx, j*dy)
+			tiles.PathOps = append(tiles.PathOps, tile.PathOps...)
+		}
+	}
+	result := tiles.Intersect(p)
+	result.Width = p.Width
+	return result
+}
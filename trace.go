@@ -0,0 +1,233 @@
+package filmore
+
+import (
+	"image"
+	"math"
+	"sort"
+)
+
+// traceTolerance is how far, in bitmap pixels, the straightened outline of a
+// traced bitmap may stray from its pixel edges. It is just over the distance
+// from a diagonal line to the inner corners of the staircase of pixels that
+// draws it.
+const traceTolerance = 0.75
+
+// TraceBitmap converts a bitmap, such as a glyph from a bitmap-only font, into
+// an outline path in the manner of potrace. A pixel is ink if it is at least
+// half opaque, so masks like *image.Alpha and images drawn on a transparent
+// background trace as expected. The top left corner of img's bounds is put
+// at x, y, and each pixel becomes a square of side scale.
+//
+// smoothing controls how much of the outline is rounded off. At 0 the path
+// follows the pixel edges exactly. Above 0 staircases are straightened into
+// lines and the gentler corners between them replaced with curves; 1 is a
+// good default, values up to 4/3 round off ever sharper corners, and beyond
+// that the outline has no corners at all. Width is set to the bitmap's
+// width.
+func TraceBitmap(img image.Image, x, y, scale, smoothing float64) TextPath {
+	b := img.Bounds()
+	ink := func(px, py int) bool {
+		if !(image.Point{px, py}).In(b) {
+			return false
+		}
+		_, _, _, a := img.At(px, py).RGBA()
+		return a >= 0x8000
+	}
+	// Outline each inked pixel, skipping edges shared with inked neighbours,
+	// with the same winding as glyphs: ink on the left with y pointing down.
+	out := map[image.Point][]image.Point{}
+	addEdge := func(x0, y0, x1, y1 int) {
+		from := image.Point{x0, y0}
+		out[from] = append(out[from], image.Point{x1 - x0, y1 - y0})
+	}
+	for py := b.Min.Y; py < b.Max.Y; py++ {
+		for px := b.Min.X; px < b.Max.X; px++ {
+			if !ink(px, py) {
+				continue
+			}
+			if !ink(px, py-1) {
+				addEdge(px, py, px+1, py)
+			}
+			if !ink(px+1, py) {
+				addEdge(px+1, py, px+1, py+1)
+			}
+			if !ink(px, py+1) {
+				addEdge(px+1, py+1, px, py+1)
+			}
+			if !ink(px-1, py) {
+				addEdge(px, py+1, px, py)
+			}
+		}
+	}
+	toPath := func(p Point) Point {
+		return Point{x + (p.X-float64(b.Min.X))*scale, y + (p.Y-float64(b.Min.Y))*scale}
+	}
+	result := TextPath{Width: float64(b.Dx()) * scale}
+	for _, loop := range traceLoops(out) {
+		loop = simplifyCollinear(loop)
+		if smoothing <= 0 {
+			for i := range loop {
+				loop[i] = toPath(loop[i])
+			}
+			result.PathOps = append(result.PathOps, pathFromLoops([][]Point{loop}).PathOps...)
+			continue
+		}
+		if straight := straightenLoop(loop, traceTolerance); len(straight) >= 3 {
+			loop = straight
+		}
+		result.appendSmoothedLoop(loop, smoothing, toPath)
+	}
+	return result
+}
+
+// traceLoops joins the unit edges leaving each lattice point into closed
+// loops. Where two edges leave the same point, pixels touch only at a corner,
+// and the loop turns so as to keep them joined. Loops are started from points
+// with a single edge, taken in order of y and then x, so that a bitmap always
+// traces the same way.
+func traceLoops(out map[image.Point][]image.Point) [][]Point {
+	points := make([]image.Point, 0, len(out))
+	for p := range out {
+		points = append(points, p)
+	}
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Y != points[j].Y {
+			return points[i].Y < points[j].Y
+		}
+		return points[i].X < points[j].X
+	})
+	var loops [][]Point
+	for len(out) > 0 {
+		traced := false
+		for _, p := range points {
+			if len(out[p]) == 1 {
+				loops = append(loops, traceLoop(out, p, image.Point{}))
+				traced = true
+			}
+		}
+		if traced {
+			continue
+		}
+		// Every point left has two edges. Enter the first along one of its
+		// incoming edges, which are at right angles to its outgoing ones.
+		for _, p := range points {
+			if dirs := out[p]; len(dirs) > 0 {
+				loops = append(loops, traceLoop(out, p, image.Point{-dirs[0].Y, dirs[0].X}))
+				break
+			}
+		}
+	}
+	return loops
+}
+
+// traceLoop follows edges from start, as though it had arrived there in the
+// direction dir, removing them from out until it comes back the same way.
+func traceLoop(out map[image.Point][]image.Point, start, dir image.Point) []Point {
+	var loop []Point
+	at, in := start, dir
+	for {
+		dirs := out[at]
+		if len(dirs) == 0 || len(loop) > 0 && at == start && dir == in {
+			return loop
+		}
+		k := 0
+		if len(dirs) > 1 && cross(float64(dir.X), float64(dir.Y), float64(dirs[1].X), float64(dirs[1].Y)) < 0 {
+			k = 1
+		}
+		loop = append(loop, Point{float64(at.X), float64(at.Y)})
+		dir = dirs[k]
+		if dirs = append(dirs[:k], dirs[k+1:]...); len(dirs) == 0 {
+			delete(out, at)
+		} else {
+			out[at] = dirs
+		}
+		at = at.Add(dir)
+	}
+}
+
+// straightenLoop approximates a closed loop by one with fewer points that
+// stays within tolerance of it, by Douglas-Peucker simplification.
+func straightenLoop(loop []Point, tolerance float64) []Point {
+	far, farDist := 0, -1.0
+	for i, p := range loop {
+		if d := math.Hypot(p.X-loop[0].X, p.Y-loop[0].Y); d > farDist {
+			far, farDist = i, d
+		}
+	}
+	result := appendSimplified(nil, loop[:far+1], tolerance)
+	return appendSimplified(result, append(append([]Point(nil), loop[far:]...), loop[0]), tolerance)
+}
+
+// appendSimplified appends to dst the points of the polyline pts, without
+// its last point, that Douglas-Peucker simplification keeps to stay within
+// tolerance of it.
+func appendSimplified(dst, pts []Point, tolerance float64) []Point {
+	split, splitDist := 0, tolerance
+	for i := 1; i < len(pts)-1; i++ {
+		if d := distToSegment(pts[i], pts[0], pts[len(pts)-1]); d > splitDist {
+			split, splitDist = i, d
+		}
+	}
+	if split == 0 {
+		return append(dst, pts[0])
+	}
+	dst = appendSimplified(dst, pts[:split+1], tolerance)
+	return appendSimplified(dst, pts[split:], tolerance)
+}
+
+// appendSmoothedLoop appends a closed polygon to the path, replacing corners
+// gentle enough for the smoothing level with curves as potrace does. Each
+// side is entered and left at its midpoint. mapPoint converts the polygon's
+// points to path coordinates.
+func (p *TextPath) appendSmoothedLoop(loop []Point, smoothing float64, mapPoint func(Point) Point) {
+	n := len(loop)
+	mid := func(a, b Point) Point { return Point{(a.X + b.X) / 2, (a.Y + b.Y) / 2} }
+	along := func(t float64, a, b Point) Point { return Point{a.X + t*(b.X-a.X), a.Y + t*(b.Y-a.Y)} }
+	start := mid(loop[n-1], loop[0])
+	s := mapPoint(start)
+	p.MoveTo(s.X, s.Y)
+	for j := range loop {
+		vi, vj, vk := loop[(j+n-1)%n], loop[j], loop[(j+1)%n]
+		end := mid(vj, vk)
+		alpha := cornerAlpha(vi, vj, vk)
+		e := mapPoint(end)
+		if alpha >= smoothing {
+			c := mapPoint(vj)
+			p.LineTo(c.X, c.Y)
+			p.LineTo(e.X, e.Y)
+		} else {
+			alpha = math.Max(0.55, math.Min(1, alpha))
+			// The cubic potrace would draw, approximated by a quadratic.
+			c1, c2 := along(0.5+0.5*alpha, vi, vj), along(0.5+0.5*alpha, vk, vj)
+			c := mapPoint(Point{(3*(c1.X+c2.X) - start.X - end.X) / 4, (3*(c1.Y+c2.Y) - start.Y - end.Y) / 4})
+			p.QuadCurveTo(e.X, e.Y, c.X, c.Y)
+		}
+		start = end
+	}
+}
+
+// cornerAlpha returns potrace's measure of the sharpness of the corner at b
+// between the sides ab and bc: small for corners that stand out little from
+// the line joining the neighbouring points, and 4/3 or more for sharp ones.
+func cornerAlpha(a, b, c Point) float64 {
+	sign := func(v float64) float64 {
+		switch {
+		case v > 0:
+			return 1
+		case v < 0:
+			return -1
+		}
+		return 0
+	}
+	// The length of ac measured across the nearest axis-aligned or diagonal
+	// direction, as potrace does.
+	denom := sign(c.X-a.X)*(c.X-a.X) + sign(c.Y-a.Y)*(c.Y-a.Y)
+	if denom == 0 {
+		return 4.0 / 3
+	}
+	dd := math.Abs(cross(b.X-a.X, b.Y-a.Y, c.X-a.X, c.Y-a.Y)) / denom
+	if dd <= 1 {
+		return 0
+	}
+	return (1 - 1/dd) / 0.75
+}
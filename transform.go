@@ -0,0 +1,106 @@
+package filmore
+
+import "math"
+
+// mapPoints returns a copy of p with fn applied to every end and control
+// point.
+func (p *TextPath) mapPoints(fn func(x, y float64) (float64, float64)) TextPath {
+	result := *p
+	result.PathOps = make([]Op, len(p.PathOps))
+	result.Sources = append([]GlyphSource(nil), p.Sources...)
+	for i, op := range p.PathOps {
+		x, y := fn(op.X(), op.Y())
+		switch op := op.(type) {
+		case MoveTo:
+			result.PathOps[i] = MoveTo{x, y}
+		case LineTo:
+			result.PathOps[i] = LineTo{x, y}
+		case ClosePath:
+			result.PathOps[i] = ClosePath{x, y}
+		case QuadCurveTo:
+			cx, cy := fn(op.cx, op.cy)
+			result.PathOps[i] = QuadCurveTo{x, y, cx, cy}
+		case CubicCurveTo:
+			cx1, cy1 := fn(op.cx1, op.cy1)
+			cx2, cy2 := fn(op.cx2, op.cy2)
+			result.PathOps[i] = CubicCurveTo{x, y, cx1, cy1, cx2, cy2}
+		}
+	}
+	return result
+}
+
+func (p *TextPath) translated(dx, dy float64) TextPath {
+	return p.mapPoints(func(x, y float64) (float64, float64) { return x + dx, y + dy })
+}
+
+// Reverse reverses the direction of each sub-path of p in place, turning
+// outer contours into counters and back, for renderers and machines that need
+// a particular direction of travel. Each sub-path keeps its place among the
+// ops, so Sources stay valid.
+func (p *TextPath) Reverse() {
+	for i := 0; ; i++ {
+		start, end, ok := p.subPath(i)
+		if !ok {
+			return
+		}
+		ops := p.PathOps[start:end]
+		if _, ok := ops[0].(MoveTo); !ok {
+			continue
+		}
+		// Each segment is redrawn from its end to the end of the segment
+		// before it, keeping its control points in reverse order.
+		reversed := []Op{MoveTo{ops[len(ops)-1].X(), ops[len(ops)-1].Y()}}
+		_, closed := ops[len(ops)-1].(ClosePath)
+		for k := len(ops) - 1; k > 0; k-- {
+			x, y := ops[k-1].X(), ops[k-1].Y()
+			switch op := ops[k].(type) {
+			case QuadCurveTo:
+				reversed = append(reversed, QuadCurveTo{x, y, op.cx, op.cy})
+			case CubicCurveTo:
+				reversed = append(reversed, CubicCurveTo{x, y, op.cx2, op.cy2, op.cx1, op.cy1})
+			default:
+				// A closed contour stays closed if it started with a line.
+				if closed && k == 1 {
+					reversed = append(reversed, ClosePath{x, y})
+				} else {
+					reversed = append(reversed, LineTo{x, y})
+				}
+			}
+		}
+		copy(ops, reversed)
+	}
+}
+
+// Transform applies the affine matrix m to every end and control point of p,
+// in place. m is given as in SVG and PDF, so that x, y becomes
+//
+//	m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]
+//
+// If m mirrors, the contours are reversed so that outer contours and counters
+// keep their winding. Width and Height are left as they were.
+func (p *TextPath) Transform(m [6]float64) {
+	*p = p.mapPoints(func(x, y float64) (float64, float64) {
+		return m[0]*x + m[2]*y + m[4], m[1]*x + m[3]*y + m[5]
+	})
+	if m[0]*m[3]-m[1]*m[2] < 0 {
+		p.Reverse()
+	}
+}
+
+// Translate moves p by dx, dy, in place.
+func (p *TextPath) Translate(dx, dy float64) {
+	p.Transform([6]float64{1, 0, 0, 1, dx, dy})
+}
+
+// Scale scales p by sx horizontally and sy vertically about the origin, in
+// place. Negative factors mirror p, as for Transform.
+func (p *TextPath) Scale(sx, sy float64) {
+	p.Transform([6]float64{sx, 0, 0, sy, 0, 0})
+}
+
+// Rotate turns p by theta radians about cx, cy, in place. As in SVG, where y
+// points down, positive angles turn clockwise on the page.
+func (p *TextPath) Rotate(theta, cx, cy float64) {
+	sin, cos := math.Sin(theta), math.Cos(theta)
+	p.Transform([6]float64{cos, sin, -sin, cos, cx - cx*cos + cy*sin, cy - cx*sin - cy*cos})
+}
@@ -0,0 +1,62 @@
+package filmore
+
+import "log"
+
+// vmetric returns the advance height and top side bearing, in font units, of
+// the given glyph from the vmtx table. ok is false if the font has no
+// vertical metrics.
+func (f *Font) vmetric(glyph int) (advance, topSideBearing int, ok bool) {
+	n, vmtx := u16(f.table("vhea"), 34), f.table("vmtx")
+	if n == 0 || vmtx == nil {
+		return 0, 0, false
+	}
+	if glyph < n {
+		return u16(vmtx, 4*glyph), i16(vmtx, 4*glyph+2), true
+	}
+	return u16(vmtx, 4*(n-1)), i16(vmtx, 4*n+2*(glyph-n)), true
+}
+
+// CreateVerticalTextPath lays s out top to bottom in a single column, as in
+// vertical Japanese and Chinese typesetting. Each glyph is centered on the
+// vertical line through x, and the top of the first glyph's vertical advance
+// is at y. Glyphs are replaced by their vertical alternates from the font's
+// vert feature where it has them.
+//
+// Advances and placement come from the vhea and vmtx tables. Fonts without
+// them advance each glyph by the font's ascent plus descent and put the
+// baseline one ascent below the top of the advance.
+//
+// Height is set to the total vertical advance, and Width to the widest
+// horizontal advance of any glyph.
+func (f *Font) CreateVerticalTextPath(s string, x, y float64) TextPath {
+	result := TextPath{}
+	vert := singleSubstitutions(f.table("GSUB"), "vert", "vrt2")
+	hhea := f.table("hhea")
+	ascent, descent := i16(hhea, 4), i16(hhea, 6)
+	starty := y
+	for _, rune := range s {
+		index := f.font.Index(rune)
+		if alt, ok := vert[index]; ok {
+			index = alt
+		}
+		if err := f.loadGlyph(index); err != nil {
+			log.Println(err)
+			return result
+		}
+		advance, tsb, ok := f.vmetric(int(index))
+		baseline := y + f.fUnitsToPixels(ascent)
+		if ok {
+			baseline = y + f.fUnitsToPixels(tsb) + fUnitsToFloat64(f.glyphBuf.B.YMax)
+		} else {
+			advance = ascent - descent
+		}
+		width := fUnitsToFloat64(f.font.HMetric(f.scale, index).AdvanceWidth)
+		f.appendLoadedGlyph(x-width/2, baseline, &result)
+		if width > result.Width {
+			result.Width = width
+		}
+		y += f.fUnitsToPixels(advance)
+		result.Height = y - starty
+	}
+	return result
+}
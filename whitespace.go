@@ -0,0 +1,76 @@
+package filmore
+
+import "unicode"
+
+// A WhitespacePolicy says which whitespace at the ends of a line to lay out.
+type WhitespacePolicy int
+
+const (
+	// WhitespacePreserve keeps all whitespace.
+	WhitespacePreserve WhitespacePolicy = 0
+	// WhitespaceTrimStart drops the whitespace the line starts with, in
+	// logical order, so that indented text starts at x.
+	WhitespaceTrimStart WhitespacePolicy = 1
+	// WhitespaceTrimEnd drops the whitespace the line ends with, so that it
+	// doesn't count towards Width.
+	WhitespaceTrimEnd WhitespacePolicy = 2
+	// WhitespaceTrim drops whitespace at both ends of the line.
+	WhitespaceTrim = WhitespaceTrimStart | WhitespaceTrimEnd
+)
+
+// invisibleMarks gives the mark ShowInvisibles draws for each kind of
+// whitespace.
+var invisibleMarks = map[rune]rune{
+	' ':      '·',
+	'\t':     '→',
+	'\u00A0': '°',
+}
+
+// trimWhitespace returns glyphs without those for the whitespace runes at the
+// ends of runes that policy drops.
+func trimWhitespace(glyphs []fontGlyph, runes []rune, policy WhitespacePolicy) []fontGlyph {
+	if policy == WhitespacePreserve {
+		return glyphs
+	}
+	start, end := 0, len(runes)
+	if policy&WhitespaceTrimStart != 0 {
+		for start < end && unicode.IsSpace(runes[start]) {
+			start++
+		}
+	}
+	if policy&WhitespaceTrimEnd != 0 {
+		for end > start && unicode.IsSpace(runes[end-1]) {
+			end--
+		}
+	}
+	kept := glyphs[:0]
+	for _, g := range glyphs {
+		if g.Cluster >= start && g.Cluster < end {
+			kept = append(kept, g)
+		}
+	}
+	return kept
+}
+
+// showInvisibles makes the whitespace glyphs draw their marks from
+// invisibleMarks. Whitespace the font has no glyph for takes the advance of
+// its mark.
+func showInvisibles(glyphs []fontGlyph, runes []rune) {
+	for i := range glyphs {
+		g := &glyphs[i]
+		mark, ok := invisibleMarks[runes[g.Cluster]]
+		if !ok || g.override != nil {
+			continue
+		}
+		p, err := g.font.CreateGlyphPath(mark, 0, 0)
+		if err != nil {
+			continue
+		}
+		if g.Glyph == 0 {
+			g.XAdvance = p.Width
+		}
+		p.Translate((g.XAdvance-p.Width)/2, 0)
+		g.override = &p
+		g.XOffset, g.YOffset = 0, 0
+	}
+}
@@ -0,0 +1,113 @@
+package filmore
+
+// ContourAreas returns the signed area of each contour of p, in square
+// pixels, in the order the contours appear. Contours wound like the outer
+// contours of glyphs, clockwise on the page since y points down, have
+// positive areas, and those wound the other way, like counters, have
+// negative ones. Curves are measured exactly, and contours that don't end
+// where they start are taken to be closed by a straight line.
+func (p *TextPath) ContourAreas() []float64 {
+	var result []float64
+	var x0, y0, startX, startY float64
+	closeContour := func() {
+		if len(result) > 0 {
+			result[len(result)-1] += cross(x0, y0, startX, startY) / 2
+		}
+	}
+	for _, op := range p.PathOps {
+		if _, ok := op.(MoveTo); ok || len(result) == 0 {
+			closeContour()
+			result = append(result, 0)
+			startX, startY = x0, y0
+			if ok {
+				startX, startY = op.X(), op.Y()
+			}
+		}
+		result[len(result)-1] += segmentArea(x0, y0, op)
+		x0, y0 = op.X(), op.Y()
+	}
+	closeContour()
+	return result
+}
+
+// segmentArea returns the signed area swept about the origin by the segment
+// op draws from x0, y0: half the integral of x dy - y dx along it. Summed
+// around a closed contour, these give the area it encloses.
+func segmentArea(x0, y0 float64, op Op) float64 {
+	x1, y1 := op.X(), op.Y()
+	switch op := op.(type) {
+	case MoveTo:
+		return 0
+	case QuadCurveTo:
+		return (2*cross(x0, y0, op.cx, op.cy) + 2*cross(op.cx, op.cy, x1, y1) + cross(x0, y0, x1, y1)) / 6
+	case CubicCurveTo:
+		return (6*cross(x0, y0, op.cx1, op.cy1) + 3*cross(x0, y0, op.cx2, op.cy2) + cross(x0, y0, x1, y1) +
+			3*cross(op.cx1, op.cy1, op.cx2, op.cy2) + 3*cross(op.cx1, op.cy1, x1, y1) +
+			6*cross(op.cx2, op.cy2, x1, y1)) / 20
+	}
+	return cross(x0, y0, x1, y1) / 2
+}
+
+// NormalizeWinding reverses contours of p in place as needed so that outer
+// contours are wound like those of well-formed glyphs and counters the other
+// way, for fonts whose inconsistent winding breaks non-zero fills. A contour
+// is taken to be a counter if it lies inside an odd number of p's other
+// contours, as for Counters.
+func (p *TextPath) NormalizeWinding() {
+	polys := p.Flatten(defaultTolerance)
+	areas := p.ContourAreas()
+	for i, poly := range polys {
+		depth := 0
+		for j, other := range polys {
+			if j != i && insidePolyline(poly[0], other) {
+				depth++
+			}
+		}
+		if outer := depth%2 == 0; outer == (areas[i] >= 0) {
+			continue
+		}
+		start, end, _ := p.subPath(i)
+		c := TextPath{PathOps: p.PathOps[start:end]}
+		c.Reverse()
+	}
+}
+
+// A FillRule decides which areas enclosed by a path's contours are inside it.
+type FillRule int
+
+const (
+	// NonZero fills areas that the contours wind around in total a number of
+	// times other than zero, counting each contour's direction, as glyph
+	// outlines are meant to be filled.
+	NonZero FillRule = iota
+	// EvenOdd fills areas that lie within an odd number of contours,
+	// whatever their direction.
+	EvenOdd
+)
+
+// Contains reports whether the point x, y lies inside p under rule, such as
+// for hit-testing a click against the shapes of the glyphs. Contours are
+// taken to be closed, and curves are followed to within a small fraction of
+// a pixel.
+func (p *TextPath) Contains(x, y float64, rule FillRule) bool {
+	winding := 0
+	for _, poly := range p.Flatten(defaultTolerance) {
+		for i := range poly {
+			a, b := poly[i], poly[(i+1)%len(poly)]
+			if (a.Y > y) == (b.Y > y) {
+				continue
+			}
+			// Count crossings of the ray to the right of the point, up or
+			// down according to the direction of the edge.
+			if c := cross(b.X-a.X, b.Y-a.Y, x-a.X, y-a.Y); b.Y > a.Y && c < 0 {
+				winding++
+			} else if b.Y < a.Y && c > 0 {
+				winding--
+			}
+		}
+	}
+	if rule == EvenOdd {
+		return winding%2 != 0
+	}
+	return winding != 0
+}